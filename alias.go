@@ -0,0 +1,41 @@
+package i18n
+
+import "strings"
+
+const aliasPrefix = "@alias("
+
+// alias returns the target key if v is an alias directive:
+//
+//	signup.button=@alias(login.button)
+func alias(v string) (string, bool) {
+	if !strings.HasPrefix(v, aliasPrefix) || !strings.HasSuffix(v, ")") {
+		return "", false
+	}
+	return strings.TrimSpace(v[len(aliasPrefix) : len(v)-1]), true
+}
+
+// follow resolves v (served by language served) while it's an alias. Target
+// keys are resolved for lang with the usual fallback chain.
+//
+// It returns false if an alias target is missing or aliases form a cycle.
+// Caller must hold mut.
+func follow(lang, v, served string) (string, string, bool) {
+	var seen []string
+	for {
+		target, ok := alias(v)
+		if !ok {
+			return v, served, true
+		}
+		for i := range seen {
+			if seen[i] == target {
+				return "", "", false
+			}
+		}
+		seen = append(seen, target)
+
+		v, served, ok = resolveKey(lang, target)
+		if !ok {
+			return "", "", false
+		}
+	}
+}
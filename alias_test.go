@@ -0,0 +1,31 @@
+package i18n
+
+import "testing"
+
+func TestAlias(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "login.button=Continue\nsignup.button=@alias(login.button)\n" +
+			"a=@alias(b)\nb=@alias(c)\nc=@alias(a)\nself=@alias(self)\n" +
+			"chain=@alias(signup.button)\nbroken=@alias(none)\n",
+		"es": "login.button=Continuar\n",
+	})
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "signup.button", "Continue"},
+		{"es", "signup.button", "Continuar"},
+		{"es-MX", "chain", "Continuar"},
+		{"en", "a", "a"},
+		{"en", "self", "self"},
+		{"en", "broken", "broken"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package i18n
+
+import "sort"
+
+// attributions contains attribution texts by lang:key.
+var attributions = make(map[string]string)
+
+// Attribution returns the attribution of the value Println returns for
+// lang+key, set with an @attribution directive (see Load). Empty if the
+// value has none.
+func Attribution(lang, key string) string {
+	mut.RLock()
+	defer mut.RUnlock()
+	key = normalizeKey(lang, key)
+	_, served, ok := resolveKey(lang, key)
+	if !ok {
+		return ""
+	}
+	return attributions[bullet(served, key)]
+}
+
+// Attributions returns sorted distinct attribution texts of the catalog,
+// e.g. for a credits page.
+func Attributions() []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	set := make(map[string]struct{})
+	for slug, text := range attributions {
+		if _, ok := langs[slug]; ok {
+			set[text] = struct{}{}
+		}
+	}
+	list := make([]string, 0, len(set))
+	for text := range set {
+		list = append(list, text)
+	}
+	sort.Strings(list)
+	return list
+}
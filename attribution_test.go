@@ -0,0 +1,38 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttribution(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "# @attribution=CC BY 4.0 Jane Doe\npoem.title=The Road\n" +
+			"# @attribution CC0 Public Domain\nquote=To be\nhome=Home\n",
+		"es": "# @attribution=CC BY 4.0 Juan Pérez\npoem.title=El camino\n" +
+			"# @attribution=CC BY 4.0 Jane Doe\nhome=Inicio\n",
+	})
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "poem.title", "CC BY 4.0 Jane Doe"},
+		{"es-MX", "poem.title", "CC BY 4.0 Juan Pérez"},
+		{"es", "quote", "CC0 Public Domain"},
+		{"en", "home", ""},
+		{"en", "none", ""},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Attribution(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+
+	expected := "CC BY 4.0 Jane Doe|CC BY 4.0 Juan Pérez|CC0 Public Domain"
+	if s := strings.Join(Attributions(), "|"); s != expected {
+		t.Fatalf("expected %q, got %q", expected, s)
+	}
+}
@@ -0,0 +1,38 @@
+package i18n
+
+// base contains the base catalog by lang:key.
+var base = make(map[string]string)
+
+// SetBaseCatalog sets a catalog (lang -> key -> value) of default strings
+// used when the loaded catalog misses a key. Useful for libraries shipping
+// built-in strings that apps can override with Load.
+//
+// Base values are tried after loaded values on each language of the
+// fallback chain, so a library translation beats an app default language
+// value. Base catalog is only used by lookups, it's not listed by Keys,
+// Languages and similar.
+func SetBaseCatalog(m map[string]map[string]string) {
+	b := make(map[string]string)
+	for lang, values := range m {
+		for key, v := range values {
+			b[bullet(lang, key)] = v
+		}
+	}
+	mut.Lock()
+	defer mut.Unlock()
+	invalidate()
+	base = b
+}
+
+// value returns the unexpired timed, loaded or base value for slug
+// (lang:key). Caller must hold mut.
+func value(slug string) (string, bool) {
+	if v, ok := timedValue(slug); ok {
+		return v, true
+	}
+	if v, ok := langs[slug]; ok {
+		return v, true
+	}
+	v, ok := base[slug]
+	return v, ok
+}
@@ -0,0 +1,35 @@
+package i18n
+
+import "testing"
+
+func TestSetBaseCatalog(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "lib.ok=Okay\napp.title=My App\n",
+	})
+	defer reset()
+	SetBaseCatalog(map[string]map[string]string{
+		"en": {"lib.ok": "OK", "lib.cancel": "Cancel"},
+		"es": {"lib.ok": "Aceptar", "lib.cancel": "Cancelar"},
+	})
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "lib.ok", "Okay"},
+		{"en", "lib.cancel", "Cancel"},
+		{"es", "lib.cancel", "Cancelar"},
+		{"es-MX", "lib.ok", "Aceptar"},
+		{"fr", "lib.ok", "Okay"},
+		{"fr", "lib.cancel", "Cancel"},
+		{"es", "app.title", "My App"},
+		{"es", "none", "none"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
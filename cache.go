@@ -0,0 +1,42 @@
+package i18n
+
+import "sync"
+
+// fallbackCacheSize bounds the fallback cache entries.
+const fallbackCacheSize = 10000
+
+var (
+	// fallbackCache contains the serving language by lang:key for lookups
+	// resolved by a fallback language, empty for misses.
+	fallbackCache = make(map[string]string)
+	cacheMut      sync.RWMutex
+)
+
+// cachedFallback returns the cached serving language for slug (lang:key).
+func cachedFallback(slug string) (string, bool) {
+	cacheMut.RLock()
+	defer cacheMut.RUnlock()
+	served, ok := fallbackCache[slug]
+	return served, ok
+}
+
+// cacheFallback stores the serving language for slug (lang:key), empty for
+// a miss. Once full the cache stops growing until the next invalidation.
+func cacheFallback(slug, served string) {
+	cacheMut.Lock()
+	defer cacheMut.Unlock()
+	if len(fallbackCache) < fallbackCacheSize {
+		fallbackCache[slug] = served
+	}
+}
+
+// invalidate clears caches, must be called on every catalog or fallback
+// settings change. Caller must hold mut write lock.
+func invalidate() {
+	cacheMut.Lock()
+	defer cacheMut.Unlock()
+	fallbackCache = make(map[string]string)
+	locales = make(map[string]*locale)
+	fmtTemplates = make(map[string]*fmtTemplate)
+	tries = make(map[string]*trie)
+}
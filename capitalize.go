@@ -0,0 +1,35 @@
+package i18n
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// CapitalizeFirst returns s with its first letter in title case following
+// lang casing rules, e.g. i becomes İ in Turkish and ß becomes Ss in German.
+// Leading punctuation like ¿ is skipped, s starting with other characters
+// like digits is returned as is and the rest of s isn't changed:
+//
+//	i18n.CapitalizeFirst("tr", "istanbul") // İstanbul
+//	i18n.CapitalizeFirst("es", "¿qué tal?") // ¿Qué tal?
+//
+// In templates:
+//
+//	{{i18ncap .Lang (i18n .Lang "label")}}
+func CapitalizeFirst(lang, s string) string {
+	for i, r := range s {
+		if unicode.IsPunct(r) || unicode.IsSpace(r) {
+			continue
+		}
+		if !unicode.IsLetter(r) {
+			break
+		}
+		n := utf8.RuneLen(r)
+		first := cases.Title(language.Make(lang)).String(s[i : i+n])
+		return s[:i] + first + s[i+n:]
+	}
+	return s
+}
@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+)
+
+func TestCapitalizeFirst(t *testing.T) {
+	table := []struct {
+		Lang     string
+		Input    string
+		Expected string
+	}{
+		{"en", "inbox messages", "Inbox messages"},
+		{"en", "iPhone", "IPhone"},
+		{"tr", "istanbul", "İstanbul"},
+		{"tr", "ısık", "Isık"},
+		{"az", "ilk", "İlk"},
+		{"de", "ßpiel", "Sspiel"},
+		{"es", "¿qué tal?", "¿Qué tal?"},
+		{"en", "already Upper", "Already Upper"},
+		{"en", "42 items", "42 items"},
+		{"en", "", ""},
+		{"", "plain", "Plain"},
+	}
+	for _, x := range table {
+		if s := CapitalizeFirst(x.Lang, x.Input); s != x.Expected {
+			t.Errorf("%s:%q expected %q, got %q", x.Lang, x.Input, x.Expected, s)
+		}
+	}
+}
+
+func TestCapitalizeFirstFuncMap(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"tr": "city=istanbul\n",
+	})
+	defer reset()
+
+	tmpl := template.Must(template.New("").Funcs(FuncMap).Parse(`{{i18ncap "tr" (i18n "tr" "city")}}`))
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s := b.String(); s != "İstanbul" {
+		t.Fatalf("expected İstanbul, got %q", s)
+	}
+}
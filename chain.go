@@ -0,0 +1,87 @@
+package i18n
+
+import "strings"
+
+// Chain translates keys walking a list of preferred languages, see
+// Resolver. It's safe for concurrent use.
+type Chain struct {
+	langs []string
+}
+
+// Resolver returns a Chain for user preferred langs in order, e.g. from a
+// profile. Build it once per request:
+//
+//	c := i18n.Resolver([]string{"es-MX", "fr"})
+//	c.T("home.title")
+//
+// Each language is followed by its base language (es for es-MX), then the
+// default language is tried.
+func Resolver(langs []string) *Chain {
+	c := &Chain{}
+	for _, lang := range langs {
+		lang = cleanLang(lang)
+		if lang == "" {
+			continue
+		}
+		if !seen(c.langs, lang) {
+			c.langs = append(c.langs, lang)
+		}
+		if i := strings.IndexAny(lang, "-_"); i > -1 && !seen(c.langs, lang[:i]) {
+			c.langs = append(c.langs, lang[:i])
+		}
+	}
+	return c
+}
+
+// PrintlnPrefs returns the translation of key for the first language of
+// prefs translating it, walking prefs per key instead of negotiating a
+// single language, then the default language. prefs are in preference
+// order, e.g. parsed from Accept-Language. Use Resolver to translate many
+// keys.
+func PrintlnPrefs(prefs []string, key string) string {
+	return Resolver(prefs).T(key)
+}
+
+// Languages returns the cleaned languages walked before the default
+// language.
+func (c *Chain) Languages() []string {
+	return append([]string(nil), c.langs...)
+}
+
+// T returns the translation of key for the first language translating it,
+// key if none does.
+func (c *Chain) T(key string) string {
+	v, _ := c.lookup(key)
+	return v
+}
+
+// Tf works like T using the translation as format for args like Printf.
+func (c *Chain) Tf(key string, args ...interface{}) string {
+	v, ok := c.lookup(key)
+	if !ok {
+		return v
+	}
+	return sprintf(v, args...)
+}
+
+// lookup returns the translation of key, false and the missing key text if
+// not found. Each language is looked up like Println without its fallback
+// chain.
+func (c *Chain) lookup(key string) (string, bool) {
+	mut.RLock()
+	defer mut.RUnlock()
+	lang := ""
+	if len(c.langs) > 0 {
+		lang = c.langs[0]
+	}
+	list := c.langs
+	if def, ok := defaultLang(normalizeKey(lang, key)); ok {
+		list = append(list[:len(list):len(list)], cleanLang(def))
+	}
+	for _, l := range list {
+		if v, _, ok := find(l, 1, key); ok {
+			return v, true
+		}
+	}
+	return missing(key), false
+}
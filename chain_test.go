@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "home=Home\nbye=Bye\nhello=Hello %s\nonly.de=Nope\n",
+		"es":    "home=Inicio\n",
+		"es-MX": "greet=Qué onda\n",
+		"fr":    "home=Accueil\nbye=Au revoir\nhello=Bonjour %s\n",
+		"de":    "only.de=Nur Deutsch\n",
+	})
+
+	c := Resolver([]string{"es-MX", "fr", "", "ES"})
+	if s := strings.Join(c.Languages(), ","); s != "es-mx,es,fr" {
+		t.Fatalf("unexpected chain %q", s)
+	}
+	table := []struct {
+		Key      string
+		Expected string
+	}{
+		{"greet", "Qué onda"},
+		{"home", "Inicio"},
+		{"bye", "Au revoir"},
+		{"only.de", "Nope"},
+		{"none", "none"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := c.T(x.Key); s != x.Expected {
+			t.Errorf("%s expected %q, got %q", x.Key, x.Expected, s)
+		}
+	}
+	if s := c.Tf("hello", "Ana"); s != "Bonjour Ana" {
+		t.Fatalf("expected Bonjour Ana, got %q", s)
+	}
+	if s := Resolver(nil).T("home"); s != "Home" {
+		t.Fatalf("expected default language, got %q", s)
+	}
+}
+
+func TestPrintlnPrefs(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home=Home\nbye=Bye\nsettings=Settings\nhelp=Help\n",
+		"pt": "home=Início\n",
+		"es": "home=Inicio\nbye=Adiós\n",
+		"fr": "home=Accueil\nbye=Au revoir\nsettings=Paramètres\n",
+	})
+	defer reset()
+
+	prefs := []string{"pt-BR", "es", "fr"}
+	table := []struct {
+		Key      string
+		Expected string
+	}{
+		{"home", "Início"},
+		{"bye", "Adiós"},
+		{"settings", "Paramètres"},
+		{"help", "Help"},
+		{"unknown", "unknown"},
+	}
+	for _, x := range table {
+		if s := PrintlnPrefs(prefs, x.Key); s != x.Expected {
+			t.Errorf("%v:%s expected %q, got %q", prefs, x.Key, x.Expected, s)
+		}
+	}
+	if s := PrintlnPrefs(nil, "home"); s != "Home" {
+		t.Fatalf("expected default language, got %q", s)
+	}
+}
+
+func TestResolverFind(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "home=Home\ntitle=Title\n",
+		"sr":    "home=Почетна\nhome.latn=Početna\n",
+		"es-mx": "landing=Inicio MX\n",
+		"es":    "landing=Inicio\n",
+	})
+	defer reset()
+	PreferLatin(true)
+	SetKeyResolver(func(lang, key string) string {
+		if key == "home.page" && lang == "es" {
+			return "landing"
+		}
+		return key
+	})
+
+	c := Resolver([]string{"fr", "sr"})
+	if s := c.T("home"); s != "Početna" {
+		t.Fatalf("expected latin variant, got %q", s)
+	}
+	// resolver gets every chain language, es-MX rewrites nothing.
+	c = Resolver([]string{"es-MX"})
+	if s := c.T("home.page"); s != "Inicio" {
+		t.Fatalf("expected key resolved for es, got %q", s)
+	}
+}
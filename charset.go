@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// CharsetAuto detects file charsets, see Charset.
+const CharsetAuto = "auto"
+
+// Charset makes Load transcode language files from charset to UTF-8.
+// charset is a name or alias as defined by the WHATWG Encoding standard,
+// like iso-8859-1, windows-1252 or utf-16le. A byte order mark in the file
+// takes precedence over charset.
+//
+// With CharsetAuto files with byte order mark are decoded as UTF-8 or
+// UTF-16, valid UTF-8 files are read as is and anything else is decoded as
+// windows-1252 (latin-1 superset).
+//
+// Files are read as UTF-8 if no Charset is given.
+func Charset(charset string) Option {
+	return func(o *options) {
+		o.charset = charset
+	}
+}
+
+// decode returns a reader transcoding r from charset to UTF-8.
+func decode(r io.Reader, charset string) (io.Reader, error) {
+	if charset != CharsetAuto {
+		enc, err := htmlindex.Get(charset)
+		if err != nil {
+			return nil, err
+		}
+		return transform.NewReader(r, unicode.BOMOverride(enc.NewDecoder())), nil
+	}
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var dec *encoding.Decoder
+	if utf8.Valid(b) {
+		dec = encoding.Nop.NewDecoder()
+	} else {
+		dec = charmap.Windows1252.NewDecoder()
+	}
+	return transform.NewReader(bytes.NewReader(b), unicode.BOMOverride(dec)), nil
+}
@@ -0,0 +1,47 @@
+package i18n
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCharset(t *testing.T) {
+	// canción=canción in latin-1.
+	latin1 := "song=canci\xf3n\n"
+	// hola=olá in UTF-16LE with byte order mark.
+	utf16 := "\xff\xfeh\x00o\x00l\x00a\x00=\x00o\x00l\x00\xe1\x00\n\x00"
+
+	table := []struct {
+		Charset  string
+		Files    map[string]string
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"iso-8859-1", map[string]string{"es": latin1}, "es", "song", "canción"},
+		{"utf-16le", map[string]string{"pt": utf16}, "pt", "hola", "olá"},
+		{CharsetAuto, map[string]string{"es": latin1}, "es", "song", "canción"},
+		{CharsetAuto, map[string]string{"pt": utf16}, "pt", "hola", "olá"},
+		{CharsetAuto, map[string]string{"fr": "song=chanson été\n"}, "fr", "song", "chanson été"},
+		{CharsetAuto, map[string]string{"fr": "\xef\xbb\xbfsong=chanson\n"}, "fr", "song", "chanson"},
+	}
+	for i := range table {
+		x := table[i]
+		reset()
+		dir := writeFiles(t, x.Files)
+		err := Load(dir, "en", "", "", Charset(x.Charset))
+		os.RemoveAll(dir)
+		if err != nil {
+			t.Fatalf("%s: load: %s", x.Charset, err)
+		}
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s: expected %q, got %q", x.Charset, x.Expected, s)
+		}
+	}
+
+	dir := writeFiles(t, map[string]string{"es": latin1})
+	defer os.RemoveAll(dir)
+	if err := Load(dir, "en", "", "", Charset("unknown-charset")); err == nil {
+		t.Fatalf("expected error for unknown charset")
+	}
+}
@@ -0,0 +1,441 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// voidTags are html elements without closing tag.
+var voidTags = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// CheckHTMLBalance reports values with unbalanced html tags and keys whose
+// tags differ across languages (compared against default language when
+// available).
+//
+// Tags are found with a lenient scanner, not an html parser.
+func CheckHTMLBalance() []error {
+	mut.RLock()
+	defer mut.RUnlock()
+
+	// key -> lang -> tags
+	keys := make(map[string]map[string]string)
+	var errs []error
+	for slug, value := range langs {
+		i := strings.Index(slug, ":")
+		lang, key := slug[:i], slug[i+1:]
+		tags, ok := scanTags(value)
+		if !ok {
+			errs = append(errs, fmt.Errorf("i18n: %s: unbalanced html tags", slug))
+			continue
+		}
+		if keys[key] == nil {
+			keys[key] = make(map[string]string)
+		}
+		keys[key][lang] = tags
+	}
+
+	def := cleanLang(defLang)
+	for key, m := range keys {
+		list := make([]string, 0, len(m))
+		for lang := range m {
+			list = append(list, lang)
+		}
+		sort.Strings(list)
+		ref := list[0]
+		if _, ok := m[def]; ok {
+			ref = def
+		}
+		for _, lang := range list {
+			if m[lang] != m[ref] {
+				errs = append(errs, fmt.Errorf("i18n: %s: html tags differ from %s", bullet(lang, key), bullet(ref, key)))
+			}
+		}
+	}
+	sort.Sort(errorList(errs))
+	return errs
+}
+
+// scanTags returns the sorted list of element names opened in s, false if
+// s contains unbalanced tags.
+func scanTags(s string) (string, bool) {
+	var stack, opened []string
+	for {
+		i := strings.Index(s, "<")
+		if i < 0 {
+			break
+		}
+		s = s[i+1:]
+		if s == "" || (s[0] != '/' && !isLetter(s[0]|0x20)) {
+			// not a tag: comparisons, comments or doctype.
+			continue
+		}
+		j := strings.Index(s, ">")
+		if j < 0 {
+			break
+		}
+		tag := s[:j]
+		s = s[j+1:]
+
+		closing := strings.HasPrefix(tag, "/")
+		selfClosing := strings.HasSuffix(tag, "/")
+		tag = strings.Trim(tag, "/ ")
+		if k := strings.IndexAny(tag, " \t\n"); k > -1 {
+			tag = tag[:k]
+		}
+		tag = strings.ToLower(tag)
+		if tag == "" || !isLetter(tag[0]) {
+			continue
+		}
+		switch {
+		case closing:
+			if len(stack) < 1 || stack[len(stack)-1] != tag {
+				return "", false
+			}
+			stack = stack[:len(stack)-1]
+		case selfClosing || voidTags[tag]:
+			opened = append(opened, tag)
+		default:
+			stack = append(stack, tag)
+			opened = append(opened, tag)
+		}
+	}
+	if len(stack) > 0 {
+		return "", false
+	}
+	sort.Strings(opened)
+	return strings.Join(opened, ","), true
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+// errorList sorts errors by message.
+type errorList []error
+
+func (e errorList) Len() int           { return len(e) }
+func (e errorList) Less(i, j int) bool { return e[i].Error() < e[j].Error() }
+func (e errorList) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+
+// CheckSimilarKeys returns pairs of default language keys within threshold
+// edits (insertions, deletions, substitutions or adjacent transpositions)
+// of each other, likely typos like home.titel and home.title. Pairs are
+// sorted, each pair sorted too.
+//
+// Numbered siblings like cta.1 and cta.2 aren't reported. To bound the cost
+// on large catalogs keys are only compared inside buckets: keys of the same
+// namespace (home.title and home.titel), keys with the same segments after
+// the namespace (hmoe.title and home.title) and keys without namespace.
+// Typos spanning the namespace and the rest of a key aren't reported. Only
+// keys with length difference up to threshold are compared.
+func CheckSimilarKeys(threshold int) [][2]string {
+	if threshold < 1 {
+		return nil
+	}
+	byNamespace := make(map[string][]string)
+	byRest := make(map[string][]string)
+	for _, key := range Keys(defaultLanguage()) {
+		i := strings.Index(key, ".")
+		if i < 0 {
+			byNamespace[""] = append(byNamespace[""], key)
+			continue
+		}
+		byNamespace[key[:i]] = append(byNamespace[key[:i]], key)
+		byRest[key[i:]] = append(byRest[key[i:]], key)
+	}
+
+	var pairs [][2]string
+	for _, list := range byNamespace {
+		pairs = similarPairs(pairs, list, threshold)
+	}
+	for _, list := range byRest {
+		pairs = similarPairs(pairs, list, threshold)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	return pairs
+}
+
+// similarPairs appends to pairs the sorted pairs of list keys within
+// threshold edits.
+func similarPairs(pairs [][2]string, list []string, threshold int) [][2]string {
+	sort.SliceStable(list, func(i, j int) bool {
+		return len(list[i]) < len(list[j])
+	})
+	for i := range list {
+		for j := i + 1; j < len(list) && len(list[j])-len(list[i]) <= threshold; j++ {
+			a, b := list[i], list[j]
+			if numberedSiblings(a, b) || bagDistance(a, b) > threshold || editDistance(a, b, threshold) > threshold {
+				continue
+			}
+			if b < a {
+				a, b = b, a
+			}
+			pairs = append(pairs, [2]string{a, b})
+		}
+	}
+	return pairs
+}
+
+// bagDistance returns a cheap lower bound of editDistance for ASCII a and
+// b: the count of characters of one missing in the other, 0 if any of them
+// isn't ASCII.
+func bagDistance(a, b string) int {
+	var count [128]int
+	for i := 0; i < len(a); i++ {
+		if a[i] >= 128 {
+			return 0
+		}
+		count[a[i]]++
+	}
+	for i := 0; i < len(b); i++ {
+		if b[i] >= 128 {
+			return 0
+		}
+		count[b[i]]--
+	}
+	var more, less int
+	for _, n := range count {
+		if n > 0 {
+			more += n
+		} else {
+			less -= n
+		}
+	}
+	if more > less {
+		return more
+	}
+	return less
+}
+
+// numberedSiblings reports if a and b only differ in a numeric last
+// segment.
+func numberedSiblings(a, b string) bool {
+	i, j := strings.LastIndex(a, "."), strings.LastIndex(b, ".")
+	if i < 0 || j < 0 || a[:i] != b[:j] {
+		return false
+	}
+	return isNumber(a[i+1:]) && isNumber(b[j+1:])
+}
+
+func isNumber(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// editDistance returns the optimal string alignment distance (Levenshtein
+// counting adjacent transpositions as one edit) between a and b, any value
+// greater than max once the distance exceeds max.
+func editDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+			if cur[j] < rowMin {
+				rowMin = cur[j]
+			}
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+func minInt(a int, list ...int) int {
+	for _, n := range list {
+		if n < a {
+			a = n
+		}
+	}
+	return a
+}
+
+// CheckControlChars returns by lang:key the suspicious characters found in
+// values, usually pasted from word processors: control codes, format
+// characters like zero-width space and spaces other than U+0020 like
+// non-breaking space. Tab, new line and carriage return aren't reported.
+func CheckControlChars() map[string][]rune {
+	mut.RLock()
+	defer mut.RUnlock()
+	m := make(map[string][]rune)
+	for slug, value := range langs {
+		var list []rune
+		for _, r := range value {
+			if isSuspicious(r) && !seenRune(list, r) {
+				list = append(list, r)
+			}
+		}
+		if len(list) > 0 {
+			m[slug] = list
+		}
+	}
+	return m
+}
+
+// CheckCharset returns sorted lang:key entries of keys whose values
+// contain characters outside allowed in any language, e.g. keys shown on an
+// ASCII only display:
+//
+//	ascii := &unicode.RangeTable{R16: []unicode.Range16{{0x20, 0x7e, 1}}}
+//	bad := i18n.CheckCharset([]string{"lcd.status"}, ascii)
+func CheckCharset(keys []string, allowed *unicode.RangeTable) []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	var list []string
+	for _, lang := range languages() {
+		for _, key := range keys {
+			slug := bullet(lang, key)
+			value, ok := langs[slug]
+			if !ok {
+				continue
+			}
+			for _, r := range value {
+				if !unicode.Is(allowed, r) {
+					list = append(list, slug)
+					break
+				}
+			}
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// CheckWidth returns sorted lang:key entries of keys whose values are
+// longer than maxRunes characters (runes, not bytes) in any language, e.g.
+// keys used in fixed width CLI tables.
+func CheckWidth(keys []string, maxRunes int) []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	var list []string
+	for _, lang := range languages() {
+		for _, key := range keys {
+			slug := bullet(lang, key)
+			if value, ok := langs[slug]; ok && utf8.RuneCountInString(value) > maxRunes {
+				list = append(list, slug)
+			}
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// CheckPluralCoverage returns by lang:key the plural categories that lang
+// rules require but plural keys don't define. Plural keys are groups
+// defining key.other and some other category, like inbox.one, so ordinary
+// keys such as options.other aren't reported. Counts of missing categories
+// render key.other, e.g. ru defining only inbox.one and inbox.other reports
+// "ru:inbox" with few and many.
+func CheckPluralCoverage() map[string][]string {
+	mut.RLock()
+	defer mut.RUnlock()
+	defined := make(map[string][]string)
+	for slug := range langs {
+		i := strings.LastIndex(slug, ".")
+		if i < 0 || !isPluralCategory(slug[i+1:]) {
+			continue
+		}
+		defined[slug[:i]] = append(defined[slug[:i]], slug[i+1:])
+	}
+	m := make(map[string][]string)
+	for base, cats := range defined {
+		if len(cats) < 2 || !seen(cats, PluralOther) {
+			continue
+		}
+		lang := base[:strings.Index(base, ":")]
+		var list []string
+		for _, cat := range pluralRuleFor(lang).categories {
+			if !seen(cats, cat) {
+				list = append(list, cat)
+			}
+		}
+		if len(list) > 0 {
+			m[base] = list
+		}
+	}
+	return m
+}
+
+// CheckTrivialDiffs returns sorted keys of target whose value equals the
+// base language value ignoring case and whitespace, likely untranslated
+// strings where only spacing or case changed, e.g. "Sign in" and "sign
+// In". Values without letters like "%d" aren't reported.
+func CheckTrivialDiffs(base, target string) []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	var list []string
+	for _, key := range keys(target) {
+		want, ok := langs[bullet(base, key)]
+		if !ok || !hasLetters(want) {
+			continue
+		}
+		if strings.EqualFold(squash(langs[bullet(target, key)]), squash(want)) {
+			list = append(list, key)
+		}
+	}
+	return list
+}
+
+// hasLetters reports if s has letters outside fmt verbs.
+func hasLetters(s string) bool {
+	if t := scanFormat(s); t.ok {
+		s = strings.Join(t.literals, "")
+	}
+	return strings.IndexFunc(s, unicode.IsLetter) > -1
+}
+
+// squash returns s without whitespace.
+func squash(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// isSuspicious reports if r is an invisible character, see
+// CheckControlChars.
+func isSuspicious(r rune) bool {
+	switch r {
+	case '\t', '\n', '\r', ' ':
+		return false
+	}
+	return unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zs, unicode.Zl, unicode.Zp)
+}
+
+func seenRune(list []rune, r rune) bool {
+	for i := range list {
+		if list[i] == r {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+package i18n
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestCheckHTMLBalance(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "a=Hello <b>World</b>\nb=Read <a href=\"/tos\">terms</a><br>\nc=1 < 2 <b>x</b>\nd=<i>x</i> and <b>y</b>\n",
+		"es": "a=Hola <b>Mundo</b>\nb=Lee los <a href=\"/tos\">términos</a>\nc=1 < 2 <b>x</b>\nd=<b>y</b> e <i>x</i>\n",
+		"fr": "a=Bonjour <b>Monde\n",
+	})
+
+	errs := CheckHTMLBalance()
+	expected := []string{
+		"i18n: es:b: html tags differ from en:b",
+		"i18n: fr:a: unbalanced html tags",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, errs)
+	}
+	for i := range expected {
+		if errs[i].Error() != expected[i] {
+			t.Fatalf("expected %q, got %q", expected[i], errs[i])
+		}
+	}
+
+	setup(t, "en", map[string]string{
+		"en": "a=<p>Hello <b>World</b></p><br/>\n",
+		"es": "a=<p>Hola <B>Mundo</B></p><br />\n",
+	})
+	if errs := CheckHTMLBalance(); len(errs) != 0 {
+		t.Fatalf("expected balanced markup, got %v", errs)
+	}
+}
+
+func TestCheckSimilarKeys(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Home\nhome.titel=Home\nhome.subtitle=Sub\n" +
+			"menu.file=File\nmenu.files=Files\ncta.1=A\ncta.2=B\n" +
+			"settings.account=Account\nhmoe.title=Home\nhello=Hello\nhelo=Hello\n",
+		"es": "home.tilte=Inicio\n",
+	})
+
+	pairs := CheckSimilarKeys(1)
+	expected := [][2]string{
+		{"hello", "helo"},
+		{"hmoe.title", "home.title"},
+		{"home.titel", "home.title"},
+		{"menu.file", "menu.files"},
+	}
+	if len(pairs) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, pairs)
+	}
+	for i := range expected {
+		if pairs[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, pairs)
+		}
+	}
+
+	pairs = CheckSimilarKeys(3)
+	expected = [][2]string{
+		{"hello", "helo"},
+		{"hmoe.title", "home.title"},
+		{"home.subtitle", "home.title"},
+		{"home.titel", "home.title"},
+		{"menu.file", "menu.files"},
+	}
+	if len(pairs) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, pairs)
+	}
+	for i := range expected {
+		if pairs[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, pairs)
+		}
+	}
+
+	if pairs := CheckSimilarKeys(0); pairs != nil {
+		t.Fatalf("expected no pairs, got %v", pairs)
+	}
+}
+
+func BenchmarkCheckSimilarKeys(b *testing.B) {
+	reset()
+	defer reset()
+	// 10000 keys of the same length in 100 namespaces.
+	rnd := rand.New(rand.NewSource(1))
+	word := func() string {
+		w := make([]byte, 8)
+		for i := range w {
+			w[i] = byte('a' + rnd.Intn(26))
+		}
+		return string(w)
+	}
+	values := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		ns := word()
+		for j := 0; j < 100; j++ {
+			values[ns+"."+word()] = "x"
+		}
+	}
+	LoadMaps("en", map[string]map[string]string{"en": values})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CheckSimilarKeys(2)
+	}
+}
+
+func TestCheckControlChars(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "clean=Hello world\tok\nzw=Sign\u200bup\nnbsp=10\u00a0km and 5\u00a0km\n",
+		"es": "mixed=\u00a0Hola\u200b\x07\n",
+	})
+	m := CheckControlChars()
+	expected := map[string][]rune{
+		"en:zw":    {'\u200b'},
+		"en:nbsp":  {'\u00a0'},
+		"es:mixed": {'\u00a0', '\u200b', '\x07'},
+	}
+	if len(m) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, m)
+	}
+	for slug, list := range expected {
+		if string(m[slug]) != string(list) {
+			t.Errorf("%s expected %q, got %q", slug, list, m[slug])
+		}
+	}
+}
+
+func TestCheckCharset(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "lcd.status=READY\nlcd.error=ERR 42\nhome=Home\n",
+		"es": "lcd.status=LISTO\nlcd.error=ERR 42 se\u00f1al\nhome=Men\u00fa\n",
+		"fr": "lcd.status=PR\u00caT\n",
+	})
+	ascii := &unicode.RangeTable{R16: []unicode.Range16{{Lo: 0x20, Hi: 0x7e, Stride: 1}}}
+	list := CheckCharset([]string{"lcd.status", "lcd.error", "lcd.none"}, ascii)
+	expected := "es:lcd.error,fr:lcd.status"
+	if s := strings.Join(list, ","); s != expected {
+		t.Fatalf("expected %q, got %q", expected, s)
+	}
+}
+
+func TestCheckWidth(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "col.speed=Speed limit\ncol.name=Name\n",
+		"de": "col.speed=Geschwindigkeitsbegrenzung\ncol.name=Name\n",
+		"es": "col.speed=L\u00edmite \u00f1and\u00fa\n",
+	})
+	list := CheckWidth([]string{"col.speed", "col.name"}, 13)
+	expected := "de:col.speed"
+	if s := strings.Join(list, ","); s != expected {
+		t.Fatalf("expected %q, got %q", expected, s)
+	}
+	if list := CheckWidth([]string{"col.speed"}, 12); len(list) != 1 {
+		t.Fatalf("expected multi byte value within width, got %v", list)
+	}
+}
+
+func TestCheckPluralCoverage(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "inbox.one=%d message\ninbox.other=%d messages\ntitle=Inbox\n",
+		"ru": "inbox.one=%d message\ninbox.other=%d messages\ncart.one=a\ncart.few=b\ncart.many=c\ncart.other=d\n",
+		"pl": "inbox.one=%d message\ninbox.few=%d messages\ninbox.other=%d messages\n" +
+			"items.one=%d item\nitems.few=%d items\n",
+		"ja": "inbox.other=%d messages\n",
+		"fr": "options.other=Autres options\nitems.one=Article\n",
+	})
+	m := CheckPluralCoverage()
+	table := []struct {
+		Key      string
+		Expected string
+	}{
+		{"ru:inbox", "few,many"},
+		{"pl:inbox", "many"},
+		{"pl:items", ""},
+		{"fr:options", ""},
+		{"fr:items", ""},
+		{"ru:cart", ""},
+		{"en:inbox", ""},
+		{"ja:inbox", ""},
+	}
+	for i := range table {
+		x := table[i]
+		if s := strings.Join(m[x.Key], ","); s != x.Expected {
+			t.Errorf("%s expected %q, got %q", x.Key, x.Expected, s)
+		}
+	}
+	if len(m) != 2 {
+		t.Fatalf("expected 2 entries, got %v", m)
+	}
+}
+
+func TestCheckTrivialDiffs(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "signin=Sign in\ntitle=Home page\nok=OK\ncount=%d\nsave=Save\nname=Name\n",
+		"es": "signin=sign In\ntitle= Home  page \nok=OK\ncount=%d\nsave=Guardar\nname=Nombre\nextra=Extra\n",
+	})
+	list := CheckTrivialDiffs("en", "es")
+	expected := "ok,signin,title"
+	if s := strings.Join(list, ","); s != expected {
+		t.Fatalf("expected %q, got %q", expected, s)
+	}
+	if list := CheckTrivialDiffs("en", "fr"); len(list) != 0 {
+		t.Fatalf("expected nothing for unknown language, got %v", list)
+	}
+}
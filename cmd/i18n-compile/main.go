@@ -0,0 +1,41 @@
+// Command i18n-compile writes Go source loading the language files of dir
+// on init, see i18n.Compile.
+//
+// Usage:
+//
+//	i18n-compile [-o file.go] dir
+//
+// Output goes to stdout without -o.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/jimmy-go/i18n"
+)
+
+func main() {
+	out := flag.String("o", "", "output file")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: i18n-compile [-o file.go] dir")
+		os.Exit(2)
+	}
+	var b bytes.Buffer
+	if err := i18n.Compile(flag.Arg(0), &b); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *out == "" {
+		os.Stdout.Write(b.Bytes())
+		return
+	}
+	if err := ioutil.WriteFile(*out, b.Bytes(), 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,31 @@
+// Command i18n-extract prints translation keys used in Go source, one per
+// line.
+//
+// Usage:
+//
+//	i18n-extract [dir]
+//
+// dir defaults to the current directory.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jimmy-go/i18n"
+)
+
+func main() {
+	dir := "."
+	if len(os.Args) > 1 {
+		dir = os.Args[1]
+	}
+	keys, err := i18n.Extract(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for _, key := range keys {
+		fmt.Println(key)
+	}
+}
@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LoadMaps merges m (lang -> key -> value) into the catalog like Load does.
+// An empty defaultLanguage keeps the current default language.
+func LoadMaps(defaultLanguage string, m map[string]map[string]string) {
+	mut.Lock()
+	defer mut.Unlock()
+	if defaultLanguage != "" {
+		defLang = defaultLanguage
+	}
+	invalidate()
+	for lang, values := range m {
+		for key, v := range values {
+			slug := bullet(lang, key)
+			langs[slug] = v
+			delete(sources, slug)
+		}
+	}
+}
+
+// Compile writes Go source loading the language files of dir with LoadMaps
+// on init, so binaries don't read files at startup. Files are read like
+// Load does with default separator and comment symbol, the default language
+// comes from the .default marker. The package is named after dir, e.g.
+// package locales for ./locales, write it outside dir:
+//
+//	i18n-compile -o internal/locales/catalog.go locales
+//
+// Directives like @deprecated aren't compiled.
+func Compile(dir string, w io.Writer, opts ...Option) error {
+	catalog, err := Parse(dir, "", "", opts...)
+	if err != nil {
+		return err
+	}
+	def, err := ioutil.ReadFile(filepath.Join(dir, defaultFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by i18n-compile. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", packageName(dir))
+	fmt.Fprintf(&b, "import \"github.com/jimmy-go/i18n\"\n\n")
+	fmt.Fprintf(&b, "func init() {\n")
+	fmt.Fprintf(&b, "i18n.LoadMaps(%s, map[string]map[string]string{\n", strconv.Quote(strings.TrimSpace(string(def))))
+	list := make([]string, 0, len(catalog))
+	for lang := range catalog {
+		list = append(list, lang)
+	}
+	sort.Strings(list)
+	for _, lang := range list {
+		fmt.Fprintf(&b, "%s: {\n", strconv.Quote(lang))
+		keys := make([]string, 0, len(catalog[lang]))
+		for key := range catalog[lang] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%s: %s,\n", strconv.Quote(key), strconv.Quote(catalog[lang][key]))
+		}
+		fmt.Fprintf(&b, "},\n")
+	}
+	fmt.Fprintf(&b, "})\n}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// packageName returns a package name for dir, translations if its name
+// isn't a valid package name.
+func packageName(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "translations"
+	}
+	name := strings.ToLower(filepath.Base(abs))
+	name = strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, name)
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return "translations"
+		}
+	}
+	if name == "" || name == "_" || token.Lookup(name).IsKeyword() {
+		return "translations"
+	}
+	return name
+}
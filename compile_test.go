@@ -0,0 +1,181 @@
+package i18n
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	reset()
+	defer reset()
+	dir := filepath.Join("testdata", "locales")
+	var b bytes.Buffer
+	if err := Compile(dir, &b); err != nil {
+		t.Fatalf("compile: %s", err)
+	}
+	golden, err := ioutil.ReadFile(filepath.Join("testdata", "compile.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(golden) {
+		t.Fatalf("expected:\n%s\ngot:\n%s", golden, b.String())
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "catalog.go", b.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("generated code doesn't parse: %s", err)
+	}
+	if f.Name.Name != "locales" {
+		t.Fatalf("expected package locales, got %s", f.Name.Name)
+	}
+	typeCheck(t, fset, f)
+	def, compiled := loadMapsArgs(t, f)
+
+	// loading the compiled catalog reproduces Load.
+	if err := Load(dir, "", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	loaded := make(map[string]string)
+	for slug, v := range langs {
+		loaded[slug] = v
+	}
+	expectedDef := defLang
+	reset()
+	LoadMaps(def, compiled)
+	if defLang != expectedDef {
+		t.Fatalf("expected default language %q, got %q", expectedDef, defLang)
+	}
+	if len(langs) != len(loaded) {
+		t.Fatalf("expected %d values, got %d", len(loaded), len(langs))
+	}
+	for slug, v := range loaded {
+		if langs[slug] != v {
+			t.Errorf("%s expected %q, got %q", slug, v, langs[slug])
+		}
+	}
+	if s := Println("es-MX", "quote"); s != "Say \"hi\"\\tnow" {
+		t.Fatalf("expected default language fallback, got %q", s)
+	}
+}
+
+func TestCompileOutputInDir(t *testing.T) {
+	reset()
+	defer reset()
+	dir := writeFiles(t, map[string]string{"en": "hello=Hello\nmulti=a=b\n"})
+	defer os.RemoveAll(dir)
+	var b bytes.Buffer
+	if err := Compile(dir, &b); err != nil {
+		t.Fatalf("compile: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "catalog.go"), b.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	warnings, err := LoadVerbose(dir, "en", "", "")
+	if err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if len(warnings) > 0 {
+		t.Fatalf("expected generated source skipped, got %v", warnings)
+	}
+	if list := Languages(); len(list) != 1 || list[0] != "en" {
+		t.Fatalf("expected only en, got %v", list)
+	}
+	var again bytes.Buffer
+	if err := Compile(dir, &again); err != nil {
+		t.Fatalf("compile: %s", err)
+	}
+	if again.String() != b.String() {
+		t.Fatalf("expected same output, got:\n%s", again.String())
+	}
+}
+
+// typeCheck type checks generated file f against the LoadMaps signature.
+func typeCheck(t *testing.T, fset *token.FileSet, f *ast.File) {
+	stub, err := parser.ParseFile(fset, "i18n.go", "package i18n\n\nvar LoadMaps "+reflect.TypeOf(LoadMaps).String()+"\n", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("github.com/jimmy-go/i18n", fset, []*ast.File{stub}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+		if path != pkg.Path() {
+			t.Fatalf("unexpected import %q", path)
+		}
+		return pkg, nil
+	})}
+	if _, err := conf.Check("locales", fset, []*ast.File{f}, nil); err != nil {
+		t.Fatalf("generated code doesn't compile: %s", err)
+	}
+}
+
+// importerFunc implements types.Importer.
+type importerFunc func(path string) (*types.Package, error)
+
+func (fn importerFunc) Import(path string) (*types.Package, error) {
+	return fn(path)
+}
+
+// loadMapsArgs returns the LoadMaps call arguments of generated file f.
+func loadMapsArgs(t *testing.T, f *ast.File) (string, map[string]map[string]string) {
+	var call *ast.CallExpr
+	ast.Inspect(f, func(n ast.Node) bool {
+		if c, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := c.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "LoadMaps" {
+				call = c
+			}
+		}
+		return call == nil
+	})
+	if call == nil || len(call.Args) != 2 {
+		t.Fatalf("LoadMaps call not found")
+	}
+	unquote := func(e ast.Expr) string {
+		s, err := strconv.Unquote(e.(*ast.BasicLit).Value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	m := make(map[string]map[string]string)
+	for _, e := range call.Args[1].(*ast.CompositeLit).Elts {
+		kv := e.(*ast.KeyValueExpr)
+		values := make(map[string]string)
+		for _, e := range kv.Value.(*ast.CompositeLit).Elts {
+			kv := e.(*ast.KeyValueExpr)
+			values[unquote(kv.Key)] = unquote(kv.Value)
+		}
+		m[unquote(kv.Key)] = values
+	}
+	return unquote(call.Args[0]), m
+}
+
+func TestPackageName(t *testing.T) {
+	table := []struct {
+		Dir      string
+		Expected string
+	}{
+		{"locales", "locales"},
+		{"/app/i18n-files", "i18n_files"},
+		{"/app/Lang.v2", "lang_v2"},
+		{"/app/2fa", "translations"},
+		{"/app/type", "translations"},
+	}
+	for _, x := range table {
+		if s := packageName(x.Dir); s != x.Expected {
+			t.Errorf("%s expected %q, got %q", x.Dir, x.Expected, s)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"context"
+	"html/template"
+)
+
+type ctxKey int
+
+const (
+	langCtxKey ctxKey = iota
+	overridesCtxKey
+)
+
+// WithLang returns a copy of ctx carrying lang, used by PrintlnCtx and
+// PrintfCtx.
+func WithLang(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, langCtxKey, lang)
+}
+
+// LangFrom returns the language stored in ctx by WithLang, empty if none.
+func LangFrom(ctx context.Context) string {
+	lang, _ := ctx.Value(langCtxKey).(string)
+	return lang
+}
+
+// WithOverrides returns a copy of ctx carrying m (key -> value) checked by
+// PrintlnCtx and PrintfCtx before the catalog. Useful for request scoped
+// strings like a brand name taken from the domain.
+//
+// Overrides already stored in ctx are kept, m takes precedence.
+func WithOverrides(ctx context.Context, m map[string]string) context.Context {
+	prev, _ := ctx.Value(overridesCtxKey).(map[string]string)
+	merged := make(map[string]string, len(prev)+len(m))
+	for k, v := range prev {
+		merged[k] = v
+	}
+	for k, v := range m {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, overridesCtxKey, merged)
+}
+
+// PrintlnCtx returns translation of key for the language stored in ctx.
+// Context overrides take precedence over the catalog.
+func PrintlnCtx(ctx context.Context, key string) string {
+	if v, ok := override(ctx, key); ok {
+		return v
+	}
+	return Println(LangFrom(ctx), key)
+}
+
+// PrintfCtx works like PrintlnCtx formatting the value with args.
+func PrintfCtx(ctx context.Context, key string, args ...interface{}) string {
+	if v, ok := override(ctx, key); ok {
+		return sprintf(v, args...)
+	}
+	return Printf(LangFrom(ctx), key, args...)
+}
+
+// override returns the ctx override for key.
+func override(ctx context.Context, key string) (string, bool) {
+	m, _ := ctx.Value(overridesCtxKey).(map[string]string)
+	v, ok := m[key]
+	return v, ok
+}
+
+// FuncMapCtx returns a copy of FuncMap adding funcs bound to ctx language
+// and overrides, so templates don't need to pass the language:
+//
+//	{{ t "home.title" }}
+//	{{ tf "home.welcome" .Name }}
+func FuncMapCtx(ctx context.Context) template.FuncMap {
+	fnmap := make(template.FuncMap, len(FuncMap)+2)
+	for k, val := range FuncMap {
+		fnmap[k] = val
+	}
+	fnmap["t"] = func(key string) (s string) {
+		defer recoverKey("t", key, &s)
+		return PrintlnCtx(ctx, key)
+	}
+	fnmap["tf"] = func(key string, args ...interface{}) (s string) {
+		defer recoverKey("tf", key, &s)
+		return PrintfCtx(ctx, key, args...)
+	}
+	return fnmap
+}
@@ -0,0 +1,67 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"testing"
+)
+
+func TestWithOverrides(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "brand=Acme\nwelcome=Welcome to %s\nhome=Home\n",
+		"es": "brand=Acme ES\nhome=Inicio\n",
+	})
+
+	ctx := WithLang(context.Background(), "es-MX")
+	if s := LangFrom(ctx); s != "es-MX" {
+		t.Fatalf("unexpected lang %q", s)
+	}
+	if s := PrintlnCtx(ctx, "brand"); s != "Acme ES" {
+		t.Fatalf("expected catalog value, got %q", s)
+	}
+
+	ctx = WithOverrides(ctx, map[string]string{"brand": "Foo Corp", "welcome": "Bienvenido a %s"})
+	ctx = WithOverrides(ctx, map[string]string{"brand": "Bar Corp"})
+
+	table := []struct {
+		Key      string
+		Expected string
+	}{
+		{"brand", "Bar Corp"},
+		{"home", "Inicio"},
+		{"none", "none"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PrintlnCtx(ctx, x.Key); s != x.Expected {
+			t.Errorf("%s expected %q, got %q", x.Key, x.Expected, s)
+		}
+	}
+	if s := PrintfCtx(ctx, "welcome", "Bar Corp"); s != "Bienvenido a Bar Corp" {
+		t.Fatalf("expected override formatted, got %q", s)
+	}
+	if s := PrintlnCtx(context.Background(), "home"); s != "Home" {
+		t.Fatalf("expected default language without ctx lang, got %q", s)
+	}
+}
+
+func TestFuncMapCtx(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Home\nhome.welcome=Welcome %s\n",
+		"es": "home.title=Inicio\nhome.welcome=Bienvenido %s\n",
+	})
+
+	ctx := WithLang(context.Background(), "es")
+	tmpl, err := template.New("").Funcs(FuncMapCtx(ctx)).Parse(`{{ t "home.title" }}: {{ tf "home.welcome" .Name }} {{ i18n "en" "home.title" }}`)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{"Name": "Ana"}); err != nil {
+		t.Fatalf("execute: %s", err)
+	}
+	if s := buf.String(); s != "Inicio: Bienvenido Ana Home" {
+		t.Fatalf("unexpected output %q", s)
+	}
+}
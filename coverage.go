@@ -0,0 +1,120 @@
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// MissingKeys returns sorted keys of default language not translated in lang.
+func MissingKeys(lang string) []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	return missingKeys(lang, keys(defLang))
+}
+
+// missingKeys returns keys not translated in lang. Caller must hold mut.
+func missingKeys(lang string, keys []string) []string {
+	var list []string
+	for _, key := range keys {
+		if _, ok := langs[bullet(lang, key)]; !ok {
+			list = append(list, key)
+		}
+	}
+	return list
+}
+
+// Coverage returns the percentage (0-100) of default language keys
+// translated in each loaded language.
+func Coverage() map[string]float64 {
+	mut.RLock()
+	defer mut.RUnlock()
+	base := keys(defLang)
+	m := make(map[string]float64)
+	for _, lang := range languages() {
+		m[lang] = coverage(len(base), len(missingKeys(lang, base)))
+	}
+	return m
+}
+
+// FullyCovered returns sorted languages translating every key in keys,
+// without fallback.
+func FullyCovered(keys []string) []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	var list []string
+	for _, lang := range languages() {
+		if len(missingKeys(lang, keys)) < 1 {
+			list = append(list, lang)
+		}
+	}
+	return list
+}
+
+func coverage(total, missing int) float64 {
+	if total < 1 {
+		return 100
+	}
+	return float64(total-missing) * 100 / float64(total)
+}
+
+// WriteCoverageReport writes per language coverage and missing keys sorted
+// by language and key:
+//
+//	en 100.0% 3/3
+//	es 66.7% 2/3
+//		home.subtitle
+func WriteCoverageReport(w io.Writer) error {
+	mut.RLock()
+	defer mut.RUnlock()
+	base := keys(defLang)
+	for _, lang := range languages() {
+		missing := missingKeys(lang, base)
+		_, err := fmt.Fprintf(w, "%s %.1f%% %d/%d\n", lang, coverage(len(base), len(missing)), len(base)-len(missing), len(base))
+		if err != nil {
+			return err
+		}
+		for _, key := range missing {
+			if _, err := fmt.Fprintf(w, "\t%s\n", key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAgainst compares default language keys with expected keys
+// returning sorted expected keys missing in catalog and catalog keys not
+// expected.
+func ValidateAgainst(expected []string) ([]string, []string) {
+	have := make(map[string]bool)
+	for _, key := range Keys(defaultLanguage()) {
+		have[key] = true
+	}
+	want := make(map[string]bool)
+	var missing, extra []string
+	for _, key := range expected {
+		if want[key] {
+			continue
+		}
+		want[key] = true
+		if !have[key] {
+			missing = append(missing, key)
+		}
+	}
+	for key := range have {
+		if !want[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+	return missing, extra
+}
+
+// defaultLanguage returns the Load default language.
+func defaultLanguage() string {
+	mut.RLock()
+	defer mut.RUnlock()
+	return defLang
+}
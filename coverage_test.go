@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Home\nhome.subtitle=Welcome\nmenu.file=File\n",
+		"es": "home.title=Inicio\nmenu.file=Archivo\n",
+		"fr": "home.title=Accueil\nextra=Extra\n",
+	})
+
+	if s := strings.Join(MissingKeys("fr"), ","); s != "home.subtitle,menu.file" {
+		t.Fatalf("unexpected missing keys %q", s)
+	}
+	if s := strings.Join(MissingKeys("en"), ","); s != "" {
+		t.Fatalf("unexpected missing keys %q", s)
+	}
+
+	c := Coverage()
+	if len(c) != 3 || c["en"] != 100 || int(c["es"]) != 66 || int(c["fr"]) != 33 {
+		t.Fatalf("unexpected coverage %v", c)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCoverageReport(&buf); err != nil {
+		t.Fatalf("report: %s", err)
+	}
+	expected := "en 100.0% 3/3\n" +
+		"es 66.7% 2/3\n" +
+		"\thome.subtitle\n" +
+		"fr 33.3% 1/3\n" +
+		"\thome.subtitle\n" +
+		"\tmenu.file\n"
+	if s := buf.String(); s != expected {
+		t.Fatalf("expected report:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestValidateAgainst(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Home\nhome.legacy=Old\nmenu.file=File\n",
+		"es": "only.es=Solo\n",
+	})
+
+	missing, extra := ValidateAgainst([]string{"menu.file", "home.title", "home.subtitle", "menu.edit", "menu.file"})
+	if s := strings.Join(missing, ","); s != "home.subtitle,menu.edit" {
+		t.Fatalf("unexpected missing keys %q", s)
+	}
+	if s := strings.Join(extra, ","); s != "home.legacy" {
+		t.Fatalf("unexpected extra keys %q", s)
+	}
+
+	missing, extra = ValidateAgainst([]string{"home.title", "home.legacy", "menu.file"})
+	if len(missing) != 0 || len(extra) != 0 {
+		t.Fatalf("expected exact match, got %v %v", missing, extra)
+	}
+}
+
+func TestFullyCovered(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "checkout.title=Checkout\ncheckout.pay=Pay\nhome=Home\n",
+		"es":    "checkout.title=Pago\ncheckout.pay=Pagar\n",
+		"es-MX": "checkout.title=Pago\n",
+		"fr":    "home=Accueil\n",
+	})
+
+	s := strings.Join(FullyCovered([]string{"checkout.title", "checkout.pay"}), ",")
+	if s != "en,es" {
+		t.Fatalf("unexpected languages %q", s)
+	}
+	s = strings.Join(FullyCovered([]string{"home"}), ",")
+	if s != "en,fr" {
+		t.Fatalf("unexpected languages %q", s)
+	}
+	s = strings.Join(FullyCovered(nil), ",")
+	if s != "en,es,es-mx,fr" {
+		t.Fatalf("unexpected languages %q", s)
+	}
+}
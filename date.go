@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"strings"
+	"time"
+)
+
+// dateLayouts contains built-in short date layouts (see time.Format) by
+// language.
+var dateLayouts = map[string]string{
+	"en":    "01/02/2006",
+	"en-gb": "02/01/2006",
+	"es":    "02/01/2006",
+	"pt":    "02/01/2006",
+	"fr":    "02/01/2006",
+	"it":    "02/01/2006",
+	"de":    "02.01.2006",
+	"nl":    "02-01-2006",
+	"ru":    "02.01.2006",
+	"pl":    "02.01.2006",
+	"ja":    "2006/01/02",
+	"zh":    "2006/01/02",
+}
+
+// dateLayoutKey overrides built-in date layouts from catalog.
+const dateLayoutKey = "i18n.date.layout"
+
+// FormatDate returns t as a short date for lang, e.g. 31/12/2017 for es.
+//
+// Layout can be overridden from catalog with key i18n.date.layout, see
+// time.Format:
+//
+//	i18n.date.layout=2 Jan 2006
+func FormatDate(lang string, t time.Time) string {
+	mut.RLock()
+	defer mut.RUnlock()
+	return formatDate(lang, t)
+}
+
+// formatDate works like FormatDate. Exact language catalog overrides go
+// first, then built-in layouts, catalog default language and built-in
+// english layout. Caller must hold mut.
+func formatDate(lang string, t time.Time) string {
+	if v, _, ok := resolveDepth(lang, dateLayoutKey, 2); ok {
+		return t.Format(v)
+	}
+	if layout := localeFor(lang).dateLayout; layout != "" {
+		return t.Format(layout)
+	}
+	if v, ok := lookupKeys(lang, dateLayoutKey); ok {
+		return t.Format(v)
+	}
+	return t.Format(dateLayouts["en"])
+}
+
+// dateLayout returns the built-in date layout of clean lang, empty if
+// unknown.
+func dateLayout(lang string) string {
+	if layout, ok := dateLayouts[lang]; ok {
+		return layout
+	}
+	if i := strings.IndexAny(lang, "-_"); i > -1 {
+		return dateLayouts[lang[:i]]
+	}
+	return ""
+}
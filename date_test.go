@@ -0,0 +1,32 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDate(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "a=a\n",
+		"fr": "i18n.date.layout=2 Jan 2006\n",
+	})
+	d := time.Date(2017, 12, 31, 10, 0, 0, 0, time.UTC)
+
+	table := []struct {
+		Lang     string
+		Expected string
+	}{
+		{"en", "12/31/2017"},
+		{"en-GB", "31/12/2017"},
+		{"es-MX", "31/12/2017"},
+		{"de", "31.12.2017"},
+		{"fr-CA", "31 Dec 2017"},
+		{"xx", "12/31/2017"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := FormatDate(x.Lang, d); s != x.Expected {
+			t.Errorf("%s expected %q, got %q", x.Lang, x.Expected, s)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package i18n
+
+import "strings"
+
+// deprecated contains replacements of deprecated keys, empty if there's
+// none.
+var deprecated = make(map[string]string)
+
+// directivePrefix starts comment directives annotating the next key.
+const directivePrefix = "@"
+
+// directive returns name and argument of a comment directive line like
+//
+//	# @deprecated home.title
+//	# @attribution=CC BY 4.0 Jane Doe
+//
+// false if line isn't a directive.
+func directive(line, commentSymbol string) (string, string, bool) {
+	if !strings.HasPrefix(line, commentSymbol) {
+		return "", "", false
+	}
+	s := strings.TrimSpace(line[len(commentSymbol):])
+	if !strings.HasPrefix(s, directivePrefix) {
+		return "", "", false
+	}
+	s = s[len(directivePrefix):]
+	name, arg := s, ""
+	if i := strings.IndexAny(s, " \t="); i > -1 {
+		name, arg = s[:i], strings.TrimSpace(s[i+1:])
+	}
+	switch name {
+	case "deprecated", "attribution":
+		return name, arg, true
+	}
+	return "", "", false
+}
+
+// warnDeprecated logs once per key if key is deprecated. Caller must hold
+// mut.
+func warnDeprecated(key string) {
+	replacement, ok := deprecated[key]
+	if !ok {
+		return
+	}
+	if replacement == "" {
+		logOnce("deprecated:"+key, "i18n: key %q is deprecated", key)
+		return
+	}
+	logOnce("deprecated:"+key, "i18n: key %q is deprecated, use %q", key, replacement)
+}
@@ -0,0 +1,35 @@
+package i18n
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestDeprecated(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "# @deprecated home.title\nhome.heading=Home\n" +
+			"#@deprecated\nold.cta=Buy\n# regular comment\nhome.title=Home %s\n",
+		"es": "home.heading=Inicio\n",
+	})
+	var buf bytes.Buffer
+	SetLogger(log.New(&buf, "", 0))
+	defer SetLogger(nil)
+
+	for i := 0; i < 3; i++ {
+		if s := Println("es", "home.heading"); s != "Inicio" {
+			t.Fatalf("expected deprecated key still served, got %q", s)
+		}
+		Printf("en", "old.cta")
+		Printf("en", "home.title", "x")
+	}
+	expected := "i18n: key \"home.heading\" is deprecated, use \"home.title\"\n" +
+		"i18n: key \"old.cta\" is deprecated\n"
+	if buf.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+	if strings.Contains(buf.String(), "regular") {
+		t.Fatalf("expected regular comments ignored")
+	}
+}
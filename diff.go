@@ -0,0 +1,40 @@
+package i18n
+
+import "sort"
+
+// DiffDirs compares language files of oldDir and newDir returning sorted
+// lang:key entries added, removed and with changed value. The catalog isn't
+// modified.
+func DiffDirs(oldDir, newDir, separator, comment string) (added, removed, changed []string, err error) {
+	before, err := Parse(oldDir, separator, comment)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	after, err := Parse(newDir, separator, comment)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for lang, m := range after {
+		for key, value := range m {
+			old, ok := before[lang][key]
+			switch {
+			case !ok:
+				added = append(added, bullet(lang, key))
+			case old != value:
+				changed = append(changed, bullet(lang, key))
+			}
+		}
+	}
+	for lang, m := range before {
+		for key := range m {
+			if _, ok := after[lang][key]; !ok {
+				removed = append(removed, bullet(lang, key))
+			}
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed, nil
+}
@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDiffDirs(t *testing.T) {
+	before := writeFiles(t, map[string]string{
+		"en": "home=Home\nbye=Bye\nold=Old\n",
+		"es": "home=Inicio\n",
+		"fr": "home=Accueil\n",
+	})
+	defer os.RemoveAll(before)
+	after := writeFiles(t, map[string]string{
+		"en": "home=Home page\nbye=Bye\nnew=New\n",
+		"es": "home=Inicio\nbye=Adiós\n",
+		"de": "home=Start\n",
+	})
+	defer os.RemoveAll(after)
+
+	setup(t, "en", map[string]string{"en": "home=Loaded\n"})
+	added, removed, changed, err := DiffDirs(before, after, "", "")
+	if err != nil {
+		t.Fatalf("diff: %s", err)
+	}
+	table := []struct {
+		Name     string
+		List     []string
+		Expected string
+	}{
+		{"added", added, "de:home,en:new,es:bye"},
+		{"removed", removed, "en:old,fr:home"},
+		{"changed", changed, "en:home"},
+	}
+	for _, x := range table {
+		if s := strings.Join(x.List, ","); s != x.Expected {
+			t.Errorf("%s expected %q, got %q", x.Name, x.Expected, s)
+		}
+	}
+	if s := Println("en", "home"); s != "Loaded" {
+		t.Fatalf("expected catalog untouched, got %q", s)
+	}
+
+	if _, _, _, err := DiffDirs(before, "/none/dir", "", ""); err == nil {
+		t.Fatalf("expected missing dir error")
+	}
+}
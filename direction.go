@@ -0,0 +1,71 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+)
+
+// rtlLangs are languages written right to left.
+var rtlLangs = map[string]bool{
+	"ar": true, "arc": true, "ckb": true, "dv": true, "fa": true,
+	"he": true, "iw": true, "ks": true, "ps": true, "sd": true,
+	"syr": true, "ug": true, "ur": true, "yi": true,
+}
+
+// Unicode directional isolates.
+const (
+	lri = "\u2066" // left-to-right isolate
+	rli = "\u2067" // right-to-left isolate
+	fsi = "\u2068" // first strong isolate
+	pdi = "\u2069" // pop directional isolate
+)
+
+// Direction returns the text direction of lang: rtl or ltr.
+func Direction(lang string) string {
+	if isRTL(lang) {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// isRTL reports if lang (or its base language) is written right to left.
+func isRTL(lang string) bool {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "-_"); i > -1 {
+		lang = lang[:i]
+	}
+	return rtlLangs[lang]
+}
+
+// Isolate wraps s in Unicode isolation marks when its direction differs from
+// lang direction, e.g. a latin username inside arabic text. Strings without
+// strongly directional characters are wrapped in first strong isolate marks.
+func Isolate(lang, s string) string {
+	switch textDirection(s) {
+	case "":
+		if s == "" {
+			return s
+		}
+		return fsi + s + pdi
+	case Direction(lang):
+		return s
+	case "rtl":
+		return rli + s + pdi
+	default:
+		return lri + s + pdi
+	}
+}
+
+// textDirection returns the direction of the first strong character of s,
+// empty if s has no letters.
+func textDirection(s string) string {
+	for _, r := range s {
+		switch {
+		case unicode.In(r, unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko):
+			return "rtl"
+		case unicode.IsLetter(r):
+			return "ltr"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package i18n
+
+import "testing"
+
+func TestDirection(t *testing.T) {
+	table := map[string]string{
+		"ar":    "rtl",
+		"ar-EG": "rtl",
+		"HE":    "rtl",
+		"fa_IR": "rtl",
+		"en":    "ltr",
+		"es-MX": "ltr",
+		"":      "ltr",
+	}
+	for lang, expected := range table {
+		if s := Direction(lang); s != expected {
+			t.Errorf("%s expected %s, got %s", lang, expected, s)
+		}
+	}
+}
+
+func TestIsolate(t *testing.T) {
+	table := []struct {
+		Lang     string
+		Input    string
+		Expected string
+	}{
+		{"ar", "John", "\u2066John\u2069"},
+		{"he", "@john_doe", "\u2066@john_doe\u2069"},
+		{"ar", "محمد", "محمد"},
+		{"ar", "123", "\u2068123\u2069"},
+		{"en", "محمد", "\u2067محمد\u2069"},
+		{"en", "John", "John"},
+		{"ar", "", ""},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Isolate(x.Lang, x.Input); s != x.Expected {
+			t.Errorf("%s %q expected %q, got %q", x.Lang, x.Input, x.Expected, s)
+		}
+	}
+}
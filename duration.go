@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// durationUnits contains built-in unit words by language: singular and
+// plural forms.
+var durationUnits = map[string]map[string][2]string{
+	"en": {
+		"day":    {"%d day", "%d days"},
+		"hour":   {"%d hour", "%d hours"},
+		"minute": {"%d minute", "%d minutes"},
+		"second": {"%d second", "%d seconds"},
+	},
+	"es": {
+		"day":    {"%d día", "%d días"},
+		"hour":   {"%d hora", "%d horas"},
+		"minute": {"%d minuto", "%d minutos"},
+		"second": {"%d segundo", "%d segundos"},
+	},
+	"pt": {
+		"day":    {"%d dia", "%d dias"},
+		"hour":   {"%d hora", "%d horas"},
+		"minute": {"%d minuto", "%d minutos"},
+		"second": {"%d segundo", "%d segundos"},
+	},
+	"fr": {
+		"day":    {"%d jour", "%d jours"},
+		"hour":   {"%d heure", "%d heures"},
+		"minute": {"%d minute", "%d minutes"},
+		"second": {"%d seconde", "%d secondes"},
+	},
+	"de": {
+		"day":    {"%d Tag", "%d Tage"},
+		"hour":   {"%d Stunde", "%d Stunden"},
+		"minute": {"%d Minute", "%d Minuten"},
+		"second": {"%d Sekunde", "%d Sekunden"},
+	},
+}
+
+// durationSteps are units used by FormatDuration from greatest to lowest.
+var durationSteps = []struct {
+	Name string
+	Size time.Duration
+}{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// FormatDuration returns d in words for lang, e.g.: 2 hours 30 minutes
+// Units smaller than a second are discarded.
+//
+// Unit words can be overridden from catalog with keys
+// i18n.duration.<unit>.<category> (units: day, hour, minute, second) where
+// category is a plural category (see Plural) falling back to other, the
+// value must contain a %d verb:
+//
+//	i18n.duration.hour.one=%d hora
+//	i18n.duration.hour.other=%d horas
+func FormatDuration(lang string, d time.Duration) string {
+	mut.RLock()
+	defer mut.RUnlock()
+	return formatDuration(lang, d)
+}
+
+// formatDuration works like FormatDuration. Caller must hold mut.
+func formatDuration(lang string, d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	var parts []string
+	for _, step := range durationSteps {
+		n := int(d / step.Size)
+		d -= time.Duration(n) * step.Size
+		if n == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf(durationUnit(lang, step.Name, n), n))
+	}
+	if len(parts) < 1 {
+		return fmt.Sprintf(durationUnit(lang, "second", 0), 0)
+	}
+	return strings.Join(parts, " ")
+}
+
+// durationUnit returns the unit format for n in lang. Exact language
+// catalog overrides go first, then built-in words, catalog default language
+// and built-in english words. Caller must hold mut.
+func durationUnit(lang, unit string, n int) string {
+	cat, i := pluralCategory(lang, n), 1
+	if cat == PluralOne {
+		i = 0
+	}
+	key := "i18n.duration." + unit + "." + cat
+	other := "i18n.duration." + unit + "." + PluralOther
+	if v, _, ok := resolveDepth(lang, key, 2); ok {
+		return v
+	}
+	if v, _, ok := resolveDepth(lang, other, 2); ok {
+		return v
+	}
+	if words := localeFor(lang).units; words != nil {
+		return words[unit][i]
+	}
+	if v, ok := lookupKeys(lang, key, other); ok {
+		return v
+	}
+	return durationUnits["en"][unit][i]
+}
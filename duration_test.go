@@ -0,0 +1,40 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home=Home\n",
+		"it": "i18n.duration.hour.one=%d ora\ni18n.duration.hour.other=%d ore\n" +
+			"i18n.duration.minute.other=%d minuti\n",
+	})
+
+	table := []struct {
+		Lang     string
+		Duration time.Duration
+		Expected string
+	}{
+		{"en", 2*time.Hour + 30*time.Minute, "2 hours 30 minutes"},
+		{"en", 25*time.Hour + time.Minute + 1*time.Second, "1 day 1 hour 1 minute 1 second"},
+		{"en", 90 * time.Second, "1 minute 30 seconds"},
+		{"en", 500 * time.Millisecond, "0 seconds"},
+		{"en", -time.Hour, "1 hour"},
+		{"es-MX", 2*time.Hour + time.Minute, "2 horas 1 minuto"},
+		{"de", 48 * time.Hour, "2 Tage"},
+		{"fr", 0, "0 seconde"},
+		{"it", time.Hour + 5*time.Minute, "1 ora 5 minuti"},
+		{"it", 3 * time.Hour, "3 ore"},
+		// it has no second override nor built-in words.
+		{"it", 2 * time.Second, "2 seconds"},
+		{"xx", 2 * time.Second, "2 seconds"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := FormatDuration(x.Lang, x.Duration); s != x.Expected {
+			t.Errorf("%s %s expected %q, got %q", x.Lang, x.Duration, x.Expected, s)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// keyFuncs are package funcs taking a translation key as second argument.
+var keyFuncs = map[string]bool{
+	"Get": true, "Plural": true, "PluralCount": true, "PluralSelect": true, "PrintfCtx": true,
+	"PrintfLocalized": true, "PrintfNamed": true, "Printf": true,
+	"PrintlnBR": true, "PrintlnCtx": true, "PrintlnDepth": true, "PrintlnE": true,
+	"PrintlnFallbackKey": true, "PrintlnNoMnemonic": true, "PrintlnPrefs": true,
+	"PrintlnTrim": true, "PrintlnVariantKey": true, "Println": true,
+	"Resolve": true, "Template": true, "Truncate": true,
+}
+
+// fallbackKeyArgs are the argument index of a second key of keyFuncs.
+var fallbackKeyArgs = map[string]int{"PrintlnFallbackKey": 2}
+
+// importPath is matched as suffix of import paths, e.g.
+// gopkg.in/jimmy-go/i18n.v0
+const importPath = "jimmy-go/i18n"
+
+// Extract returns sorted keys used as string literals in calls to package
+// funcs like Println and Printf found in Go files of srcDir and its
+// subdirectories. vendor, testdata and hidden directories are skipped.
+//
+// Calls are matched by the package import name, keys built at runtime and
+// Translator methods aren't found.
+func Extract(srcDir string) ([]string, error) {
+	set := make(map[string]struct{})
+	fset := token.NewFileSet()
+	err := filepath.Walk(srcDir, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			base := info.Name()
+			if name != srcDir && (base == "vendor" || base == "testdata" || strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return err
+		}
+		extractFile(f, set)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]string, 0, len(set))
+	for key := range set {
+		list = append(list, key)
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// extractFile adds keys used in f to set.
+func extractFile(f *ast.File, set map[string]struct{}) {
+	pkg := ""
+	for _, imp := range f.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if !strings.HasSuffix(p, importPath) && !strings.Contains(p, importPath+".") {
+			continue
+		}
+		pkg = "i18n"
+		if imp.Name != nil {
+			pkg = imp.Name.Name
+		}
+	}
+	if pkg == "" || pkg == "_" {
+		return
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 {
+			return true
+		}
+		var name string
+		switch fn := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := fn.X.(*ast.Ident); ok && x.Name == pkg {
+				name = fn.Sel.Name
+			}
+		case *ast.Ident:
+			if pkg == "." {
+				name = fn.Name
+			}
+		}
+		if !keyFuncs[name] {
+			return true
+		}
+		addKey(set, call.Args[1])
+		if i, ok := fallbackKeyArgs[name]; ok && i < len(call.Args) {
+			addKey(set, call.Args[i])
+		}
+		return true
+	})
+}
+
+// addKey adds arg to set if it's a string literal.
+func addKey(set map[string]struct{}, arg ast.Expr) {
+	lit, ok := arg.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return
+	}
+	if key, err := strconv.Unquote(lit.Value); err == nil {
+		set[key] = struct{}{}
+	}
+}
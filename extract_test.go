@@ -0,0 +1,73 @@
+package i18n
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"github.com/jimmy-go/i18n"
+)
+
+func main() {
+	lang := "es"
+	fmt.Println(i18n.Println(lang, "home.title"))
+	fmt.Println(i18n.Printf(lang, "greet", "Ana"))
+	fmt.Println(i18n.Plural(lang, ` + "`inbox`" + `, 3))
+	fmt.Println(i18n.Println(lang, "dynamic."+lang))
+	fmt.Println(i18n.PrintlnFallbackKey(lang, "promo.new", "promo.default"))
+	fmt.Println(i18n.PrintlnVariantKey(lang, "cta", "short"))
+	fmt.Println(i18n.Template(lang, "preview.body"))
+	fmt.Println(i18n.Languages())
+	fmt.Printf("not %s", "a key")
+}
+`,
+		"web/handler.go": `package web
+
+import (
+	tr "gopkg.in/jimmy-go/i18n.v0"
+)
+
+func title(lang string) string {
+	return tr.PrintlnBR(lang, "web.title") + string(tr.Get(lang, "web.subtitle").Value)
+}
+`,
+		"web/other.go": `package web
+
+import "strings"
+
+func other(lang string) string {
+	return strings.Replace(lang, "x", "y", 1)
+}
+`,
+		"vendor/lib/lib.go": `package lib
+
+import "github.com/jimmy-go/i18n"
+
+var _ = i18n.Println("en", "vendored")
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	keys, err := Extract(dir)
+	if err != nil {
+		t.Fatalf("extract: %s", err)
+	}
+	expected := "cta,greet,home.title,inbox,preview.body,promo.default,promo.new,web.subtitle,web.title"
+	if s := strings.Join(keys, ","); s != expected {
+		t.Fatalf("expected %q, got %q", expected, s)
+	}
+
+	broken := writeFiles(t, map[string]string{"bad.go": "package"})
+	defer os.RemoveAll(broken)
+	if _, err := Extract(broken); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
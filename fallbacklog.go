@@ -0,0 +1,65 @@
+package i18n
+
+// FallbackLog selects Println, Printf and friends lookups logged, see
+// SetFallbackLogging.
+type FallbackLog int
+
+// Lookup steps logged by SetFallbackLogging.
+const (
+	// LogMiss logs lookups not found in any language, the key is returned.
+	LogMiss FallbackLog = 1 << iota
+	// LogRegion logs lookups served by the base language of a region, e.g.
+	// es for es-MX.
+	LogRegion
+	// LogDefault logs lookups served by the default language.
+	LogDefault
+	// LogNone disables logging.
+	LogNone FallbackLog = 0
+)
+
+// fallbackLog contains logged steps.
+var fallbackLog = LogMiss
+
+// SetFallbackLogging sets which Println, Printf and friends lookups are
+// logged, combined with |, e.g. to debug a region:
+//
+//	i18n.SetFallbackLogging(i18n.LogMiss | i18n.LogRegion | i18n.LogDefault)
+//
+// Only misses are logged by default. Messages go to SetLogger logger.
+func SetFallbackLogging(steps FallbackLog) {
+	mut.Lock()
+	defer mut.Unlock()
+	fallbackLog = steps
+}
+
+// logMiss logs a lookup of lang+key not found. Caller must hold mut.
+func logMiss(lang, key string) {
+	if fallbackLog&LogMiss != 0 {
+		logf("i18n: lang [%s] key [%s] not found", lang, key)
+	}
+}
+
+// logServed logs a lookup of lang+key found by a fallback step. Caller must
+// hold mut.
+func logServed(lang, key string) {
+	if fallbackLog&(LogRegion|LogDefault) == 0 {
+		return
+	}
+	l := cleanLang(lang)
+	k := normalizeKey(lang, key)
+	if _, ok := value(l + ":" + k); ok {
+		return
+	}
+	if base, ok := fallback(l, k, 1); ok {
+		if _, ok := value(base + ":" + k); ok {
+			if fallbackLog&LogRegion != 0 {
+				logf("i18n: lang [%s] key [%s] served by region fallback [%s]", lang, key, base)
+			}
+			return
+		}
+	}
+	if fallbackLog&LogDefault != 0 {
+		def, _ := defaultLang(k)
+		logf("i18n: lang [%s] key [%s] served by default language [%s]", lang, key, cleanLang(def))
+	}
+}
@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestSetFallbackLogging(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "hello=Hello\nbye=Bye\ngreet=Hello %s\n",
+		"es": "hello=Hola\n",
+	})
+	defer reset()
+	var buf bytes.Buffer
+	SetLogger(log.New(&buf, "", 0))
+	defer SetLogger(nil)
+
+	lookups := func() {
+		Println("es", "hello")
+		Println("es-MX", "hello")
+		Println("es", "bye")
+		Printf("es", "greet", "Ana")
+		Println("es", "none")
+		Printf("es", "nonef", 1)
+	}
+	table := []struct {
+		Steps    FallbackLog
+		Expected []string
+	}{
+		{LogMiss, []string{
+			"i18n: lang [es] key [none] not found",
+			"i18n: lang [es] key [nonef] not found",
+		}},
+		{LogRegion, []string{
+			"i18n: lang [es-MX] key [hello] served by region fallback [es]",
+		}},
+		{LogDefault, []string{
+			"i18n: lang [es] key [bye] served by default language [en]",
+			"i18n: lang [es] key [greet] served by default language [en]",
+		}},
+		{LogNone, nil},
+	}
+	for _, x := range table {
+		buf.Reset()
+		SetFallbackLogging(x.Steps)
+		lookups()
+		if s := strings.TrimSpace(buf.String()); s != strings.Join(x.Expected, "\n") {
+			t.Errorf("steps %d expected:\n%s\ngot:\n%s", x.Steps, strings.Join(x.Expected, "\n"), s)
+		}
+	}
+
+	buf.Reset()
+	SetFallbackLogging(LogMiss | LogRegion | LogDefault)
+	lookups()
+	if n := strings.Count(buf.String(), "\n"); n != 5 {
+		t.Fatalf("expected 5 logged lookups, got %d:\n%s", n, buf.String())
+	}
+}
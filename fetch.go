@@ -0,0 +1,81 @@
+package i18n
+
+var (
+	// fetcher is called on catalog misses, see SetFetcher.
+	fetcher func(lang, key string) (string, bool)
+	// cacheFetched stores fetched values in the catalog.
+	cacheFetched bool
+)
+
+// SetFetcher sets fn to be called when Println, Printf and the other lookup
+// funcs like PrintfNamed or Get don't find lang+key in the catalog, e.g. to
+// get strings from a remote translation service. fn returns false if it doesn't have the key either, then the key
+// is returned as usual. fn runs without holding the catalog lock, so it can
+// call i18n funcs. nil disables fetching.
+func SetFetcher(fn func(lang, key string) (string, bool)) {
+	mut.Lock()
+	defer mut.Unlock()
+	fetcher = fn
+}
+
+// SetFetcherCache stores values found by the SetFetcher func in the catalog
+// for lang, so next lookups don't call it. Disabled by default.
+func SetFetcherCache(enabled bool) {
+	mut.Lock()
+	defer mut.Unlock()
+	cacheFetched = enabled
+}
+
+// translate returns the translation for lang+key asking fetcher on misses,
+// false and the missing key text if not found.
+func translate(lang, key string) (string, bool) {
+	v, _, ok := translateKeys(lang, fallbackLevels, key)
+	return v, ok
+}
+
+// translateKeys works like translate returning the serving language too,
+// keys are tried in order instead of key walking levels of the fallback
+// chain like find does. Misses are recorded, fetched and logged for key.
+func translateKeys(lang string, levels int, key string, keys ...string) (string, string, bool) {
+	if len(keys) < 1 {
+		keys = []string{key}
+	}
+	v, served, ok, fn := lookupFetcher(lang, levels, key, keys)
+	if ok || fn == nil {
+		return v, served, ok
+	}
+
+	v, ok = fn(lang, key)
+	if !ok {
+		mut.RLock()
+		defer mut.RUnlock()
+		logMiss(lang, key)
+		return missing(key), "", false
+	}
+	mut.Lock()
+	defer mut.Unlock()
+	if cacheFetched {
+		langs[bullet(lang, normalizeKey(lang, key))] = v
+		invalidate()
+	}
+	return process(v), cleanLang(lang), true
+}
+
+// lookupFetcher returns the catalog translation of keys for lang and the
+// serving language, on a miss the fetcher to call for key or if there's
+// none the missing key text.
+func lookupFetcher(lang string, levels int, key string, keys []string) (string, string, bool, func(lang, key string) (string, bool)) {
+	mut.RLock()
+	defer mut.RUnlock()
+	if v, found, served, ok := findKey(lang, levels, keys...); ok {
+		recordPending(lang, found)
+		logServed(lang, found)
+		return v, served, true, nil
+	}
+	recordPending(lang, key)
+	if fetcher != nil {
+		return "", "", false, fetcher
+	}
+	logMiss(lang, key)
+	return missing(key), "", false, nil
+}
@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestSetFetcher(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "hello=Hello\n",
+	})
+	defer reset()
+
+	var calls int
+	SetFetcher(func(lang, key string) (string, bool) {
+		calls++
+		// fetcher can use the catalog.
+		if key == "remote.greet" {
+			return Println(lang, "hello") + " %s", true
+		}
+		return "", false
+	})
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"es", "hello", "Hello"},
+		{"es", "remote.greet", "Hello %s"},
+		{"es", "none", "none"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected fetcher called on misses only, got %d calls", calls)
+	}
+	if s := Printf("es", "remote.greet", "Ana"); s != "Hello Ana" {
+		t.Fatalf("expected fetched value formatted, got %q", s)
+	}
+	if calls != 3 {
+		t.Fatalf("expected no cache by default, got %d calls", calls)
+	}
+
+	SetFetcherCache(true)
+	Println("es", "remote.greet")
+	Println("es", "remote.greet")
+	if calls != 4 {
+		t.Fatalf("expected fetched value cached, got %d calls", calls)
+	}
+	if list := Keys("es"); len(list) != 1 || list[0] != "remote.greet" {
+		t.Fatalf("expected cached key in catalog, got %v", list)
+	}
+
+	SetFetcher(nil)
+	if s := Println("es", "none"); s != "none" {
+		t.Fatalf("expected key returned, got %q", s)
+	}
+}
+
+func TestMissHandling(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "hello=Hello\n",
+	})
+	defer reset()
+	var buf bytes.Buffer
+	SetLogger(log.New(&buf, "", 0))
+	defer SetLogger(nil)
+	SetFallbackLogging(LogMiss)
+	SetPendingTranslations(true)
+	var fetched []string
+	SetFetcher(func(lang, key string) (string, bool) {
+		fetched = append(fetched, key)
+		return "", false
+	})
+
+	lookups := map[string]func(){
+		"named":    func() { PrintfNamed("es", "named", nil) },
+		"select":   func() { PluralSelect("es", "select", 2, "") },
+		"local":    func() { PrintfLocalized("es", "local") },
+		"renamed":  func() { PrintlnFallbackKey("es", "renamed", "old") },
+		"variant":  func() { PrintlnVariantKey("es", "variant", "b") },
+		"depth":    func() { PrintlnDepth("es", "depth", 2) },
+		"get":      func() { Get("es", "get") },
+		"printf":   func() { Printf("es", "printf") },
+		"println":  func() { Println("es", "println") },
+		"fallback": func() { PrintlnFallbackKey("es", "fallback", "hello") },
+	}
+	for key, fn := range lookups {
+		buf.Reset()
+		fetched = nil
+		fn()
+		if key == "fallback" {
+			if buf.Len() > 0 || len(fetched) > 0 {
+				t.Errorf("expected hit not logged nor fetched, got %q %v", buf.String(), fetched)
+			}
+			continue
+		}
+		if s := strings.TrimSpace(buf.String()); s != "i18n: lang [es] key ["+key+"] not found" {
+			t.Errorf("%s: expected miss logged, got %q", key, s)
+		}
+		if len(fetched) != 1 || fetched[0] != key {
+			t.Errorf("%s: expected fetcher called, got %v", key, fetched)
+		}
+	}
+	pending := make(map[string]bool)
+	for _, p := range PendingTranslations() {
+		pending[p.Key] = true
+	}
+	// es is served hello by the default language.
+	delete(lookups, "fallback")
+	lookups["hello"] = nil
+	for key := range lookups {
+		if !pending[key] {
+			t.Errorf("%s: expected pending translation, got %v", key, pending)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var errNewLine = errors.New("i18n: value must not contain new lines")
+
+// RewriteFile replaces the value of key in language file path keeping
+// every other line (comments, order, formatting) verbatim. Lines must use
+// the default separator (=) and comment symbol (#).
+//
+// The loaded catalog isn't modified, reload it to see the change.
+func RewriteFile(path, key, newValue string) error {
+	if strings.ContainsAny(newValue, "\r\n") {
+		return errNewLine
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(b), "\n")
+	var found bool
+	for i, line := range lines {
+		cr := strings.HasSuffix(line, "\r")
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, _, err := processLine(line, "=")
+		if err != nil || k != key {
+			continue
+		}
+		found = true
+		lines[i] = k + "=" + newValue
+		if cr {
+			lines[i] += "\r"
+		}
+	}
+	if !found {
+		return fmt.Errorf("i18n: key %q not found in %s", key, path)
+	}
+	return writeFile(path, []byte(strings.Join(lines, "\n")))
+}
+
+// Canonicalize rewrites language file path in a diff friendly form: keys
+// sorted, one blank line before keys with comments and a single trailing
+// new line. Comments right above a key move with it, leading comments
+// separated by a blank line are kept as file header. Running it again
+// doesn't change the file.
+//
+// The loaded catalog doesn't change: key and value lines are written
+// verbatim and keys are sorted only between @include lines, which keep
+// their place so later values still win. Lines without separator are kept
+// like comments.
+func Canonicalize(path, separator, comment string) error {
+	if separator == "" {
+		separator = "="
+	}
+	if comment == "" {
+		comment = "#"
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	nl := "\n"
+	if strings.Contains(string(b), "\r\n") {
+		nl = "\r\n"
+	}
+
+	type entry struct {
+		key      string
+		line     string
+		comments []string
+	}
+	// segment are the entries after an @include line and its comments.
+	type segment struct {
+		include []string
+		entries []entry
+	}
+	var header, pending []string
+	segments := []segment{{}}
+	started := false
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case line == "":
+			if !started {
+				header = append(header, pending...)
+				pending = nil
+			}
+		case strings.HasPrefix(line, includeDirective):
+			started = true
+			segments = append(segments, segment{include: append(pending, line)})
+			pending = nil
+		case strings.HasPrefix(line, comment):
+			pending = append(pending, line)
+		default:
+			key, _, err := processLine(line, separator)
+			if err != nil {
+				pending = append(pending, line)
+				continue
+			}
+			started = true
+			last := &segments[len(segments)-1]
+			last.entries = append(last.entries, entry{key, line, pending})
+			pending = nil
+		}
+	}
+
+	var blocks [][]string
+	if len(header) > 0 {
+		blocks = append(blocks, header)
+	}
+	for _, seg := range segments {
+		if len(seg.include) > 0 {
+			blocks = append(blocks, seg.include)
+		}
+		sort.SliceStable(seg.entries, func(i, j int) bool {
+			return seg.entries[i].key < seg.entries[j].key
+		})
+		var block []string
+		for _, e := range seg.entries {
+			if len(e.comments) > 0 && len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			block = append(block, e.comments...)
+			block = append(block, e.line)
+		}
+		if len(block) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+	if len(pending) > 0 {
+		blocks = append(blocks, pending)
+	}
+
+	var out []string
+	for i := range blocks {
+		if i > 0 {
+			out = append(out, "")
+		}
+		out = append(out, blocks[i]...)
+	}
+	if len(out) == 0 {
+		return writeFile(path, nil)
+	}
+	return writeFile(path, []byte(strings.Join(out, nl)+nl))
+}
+
+// writeFile replaces path content atomically keeping its permissions.
+func writeFile(path string, b []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), ".i18n")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), info.Mode()); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
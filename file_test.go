@@ -0,0 +1,140 @@
+package i18n
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRewriteFile(t *testing.T) {
+	content := "# Spanish translations\n" +
+		"\n" +
+		"home.title=Inicio\n" +
+		"# menu section\n" +
+		"menu.file=Archivo\n" +
+		"menu.edit=Editar = cambiar\n" +
+		"no separator line\n"
+	dir := writeFiles(t, map[string]string{"es": content})
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "es")
+
+	if err := RewriteFile(name, "menu.file", "Fichero"); err != nil {
+		t.Fatalf("rewrite: %s", err)
+	}
+	if err := RewriteFile(name, "menu.edit", "Edición"); err != nil {
+		t.Fatalf("rewrite: %s", err)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	expected := "# Spanish translations\n" +
+		"\n" +
+		"home.title=Inicio\n" +
+		"# menu section\n" +
+		"menu.file=Fichero\n" +
+		"menu.edit=Edición\n" +
+		"no separator line\n"
+	if string(b) != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, b)
+	}
+
+	if err := RewriteFile(name, "menu.none", "x"); err == nil {
+		t.Fatalf("expected error for missing key")
+	}
+	if err := RewriteFile(name, "menu.file", "a\nb"); err == nil {
+		t.Fatalf("expected error for multi line value")
+	}
+
+	crlf := writeFiles(t, map[string]string{"en": "# c\r\na=A\r\nb=B"})
+	defer os.RemoveAll(crlf)
+	name = filepath.Join(crlf, "en")
+	if err := RewriteFile(name, "b", "Bee"); err != nil {
+		t.Fatalf("rewrite: %s", err)
+	}
+	if err := RewriteFile(name, "a", "Ay"); err != nil {
+		t.Fatalf("rewrite: %s", err)
+	}
+	b, _ = ioutil.ReadFile(name)
+	if string(b) != "# c\r\na=Ay\r\nb=Bee" {
+		t.Fatalf("expected line endings preserved, got %q", b)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	content := "# Spanish translations\n" +
+		"\n" +
+		"\n" +
+		"menu.file=Archivo\n" +
+		"@include common\n" +
+		"# greeting shown on top\n" +
+		"home.title=Inicio\n" +
+		"\n" +
+		"a.first = con espacios\n" +
+		"\n" +
+		"\n" +
+		"# about page\n" +
+		"about=Acerca\n" +
+		"x=Override\n" +
+		"# trailing note\n"
+	dir := writeFiles(t, map[string]string{"es": content, "common": "x=X\nmenu.file=File\n"})
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "es")
+	before, err := Parse(dir, "", "")
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+
+	expected := "# Spanish translations\n" +
+		"\n" +
+		"menu.file=Archivo\n" +
+		"\n" +
+		"@include common\n" +
+		"\n" +
+		"a.first = con espacios\n" +
+		"\n" +
+		"# about page\n" +
+		"about=Acerca\n" +
+		"\n" +
+		"# greeting shown on top\n" +
+		"home.title=Inicio\n" +
+		"x=Override\n" +
+		"\n" +
+		"# trailing note\n"
+	for i := 0; i < 3; i++ {
+		if err := Canonicalize(name, "", ""); err != nil {
+			t.Fatalf("canonicalize: %s", err)
+		}
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			t.Fatalf("read: %s", err)
+		}
+		if string(b) != expected {
+			t.Fatalf("run %d expected:\n%s\ngot:\n%s", i, expected, b)
+		}
+	}
+	after, err := Parse(dir, "", "")
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Fatalf("expected same catalog, before %v, after %v", before, after)
+	}
+
+	crlf := writeFiles(t, map[string]string{"en": "b:B\r\n; c\r\na:A"})
+	defer os.RemoveAll(crlf)
+	name = filepath.Join(crlf, "en")
+	if err := Canonicalize(name, ":", ";"); err != nil {
+		t.Fatalf("canonicalize: %s", err)
+	}
+	b, _ := ioutil.ReadFile(name)
+	if string(b) != "; c\r\na:A\r\nb:B\r\n" {
+		t.Fatalf("unexpected crlf output %q", b)
+	}
+
+	if err := Canonicalize(filepath.Join(dir, "none"), "", ""); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+}
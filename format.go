@@ -0,0 +1,238 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// fmtTemplate is a translation pre-scanned for fmt verbs.
+type fmtTemplate struct {
+	// literals has one more element than verbs, verb i goes between
+	// literals i and i+1.
+	literals []string
+	verbs    []string
+	// size is the literals length.
+	size int
+	// ok is false for formats sprintf can't split by verb like explicit
+	// argument indexes, * widths or a trailing %.
+	ok bool
+}
+
+// fmtTemplates contains scanned templates by format, guarded by cacheMut
+// and cleared by invalidate.
+var fmtTemplates = make(map[string]*fmtTemplate)
+
+// bufPool contains buffers formatting args fmt is needed for.
+var bufPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// sprintf works like fmt.Sprintf caching the verb positions of format. It
+// doesn't need mut, the cache is guarded by cacheMut.
+func sprintf(format string, args ...interface{}) string {
+	t := templateFor(format)
+	if !t.ok || len(t.verbs) != len(args) {
+		return fmt.Sprintf(format, args...)
+	}
+	return string(appendTemplate(make([]byte, 0, t.size+8*len(args)), t, args))
+}
+
+// AppendPrintf works like Printf appending the translation to dst and
+// returning the extended buffer, so hot paths rendering many strings can
+// reuse one buffer:
+//
+//	b = i18n.AppendPrintf(b[:0], lang, "inbox", name, count)
+//
+// Strings and ints with plain verbs are appended directly, other args are
+// formatted in pooled buffers, no intermediate strings are allocated.
+func AppendPrintf(dst []byte, lang, key string, args ...interface{}) []byte {
+	v, ok := translate(lang, key)
+	if !ok {
+		return append(dst, v...)
+	}
+	t := templateFor(v)
+	if !t.ok || len(t.verbs) != len(args) {
+		buf := bufPool.Get().(*bytes.Buffer)
+		fmt.Fprintf(buf, v, args...)
+		dst = append(dst, buf.Bytes()...)
+		buf.Reset()
+		bufPool.Put(buf)
+		return dst
+	}
+	return appendTemplate(dst, t, args)
+}
+
+// appendTemplate appends t formatted with args to b, args must match t
+// verbs.
+func appendTemplate(b []byte, t *fmtTemplate, args []interface{}) []byte {
+	for i := range t.verbs {
+		b = append(b, t.literals[i]...)
+		b = appendArg(b, t.verbs[i], args[i])
+	}
+	return append(b, t.literals[len(t.verbs)]...)
+}
+
+// templateFor returns the cached template of format.
+func templateFor(format string) *fmtTemplate {
+	cacheMut.RLock()
+	t, ok := fmtTemplates[format]
+	cacheMut.RUnlock()
+	if ok {
+		return t
+	}
+	t = scanFormat(format)
+	cacheMut.Lock()
+	if len(fmtTemplates) < fallbackCacheSize {
+		fmtTemplates[format] = t
+	}
+	cacheMut.Unlock()
+	return t
+}
+
+// appendArg appends arg formatted with verb to b. Strings and ints with
+// plain verbs skip fmt.
+func appendArg(b []byte, verb string, arg interface{}) []byte {
+	switch arg := arg.(type) {
+	case string:
+		if verb == "%s" || verb == "%v" {
+			return append(b, arg...)
+		}
+	case int:
+		if verb == "%d" || verb == "%v" {
+			return strconv.AppendInt(b, int64(arg), 10)
+		}
+	}
+	buf := bufPool.Get().(*bytes.Buffer)
+	fmt.Fprintf(buf, verb, arg)
+	b = append(b, buf.Bytes()...)
+	buf.Reset()
+	bufPool.Put(buf)
+	return b
+}
+
+// scanFormat splits format in literals and verbs.
+func scanFormat(format string) *fmtTemplate {
+	t := &fmtTemplate{ok: true}
+	var lit []byte
+	for i := 0; i < len(format); {
+		if format[i] != '%' {
+			lit = append(lit, format[i])
+			i++
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			lit = append(lit, '%')
+			i += 2
+			continue
+		}
+
+		// flags, width and precision.
+		j := i + 1
+		for j < len(format) && isVerbModifier(format[j]) {
+			j++
+		}
+		if j == len(format) || format[j] == '[' || format[j] == '*' {
+			return &fmtTemplate{}
+		}
+		_, n := utf8.DecodeRuneInString(format[j:])
+		t.literals = append(t.literals, string(lit))
+		t.verbs = append(t.verbs, format[i:j+n])
+		t.size += len(lit)
+		lit = lit[:0]
+		i = j + n
+	}
+	t.literals = append(t.literals, string(lit))
+	t.size += len(lit)
+	return t
+}
+
+// Template returns the stored value of key for lang without rendering it
+// and the number of args it needs, e.g. 2 for "%s has %d messages". %% isn't
+// a verb, explicit indexes ("%[1]s") and * widths are counted as fmt
+// consumes them. SetPipeline funcs aren't applied.
+func Template(lang, key string) (tmpl string, verbs int, found bool) {
+	mut.RLock()
+	defer mut.RUnlock()
+	v, _, ok := findRaw(lang, fallbackLevels, key)
+	if !ok {
+		return "", 0, false
+	}
+	return v, countArgs(v), true
+}
+
+// countArgs returns the number of args format consumes.
+func countArgs(format string) int {
+	var arg, max int
+	// index parses an explicit argument index at format[j:] returning the
+	// position after it.
+	index := func(j int) int {
+		if j >= len(format) || format[j] != '[' {
+			return j
+		}
+		k := strings.IndexByte(format[j:], ']')
+		if k < 0 {
+			return j
+		}
+		if n, err := strconv.Atoi(format[j+1 : j+k]); err == nil && n > 0 {
+			arg = n - 1
+		}
+		return j + k + 1
+	}
+	consume := func() {
+		arg++
+		if arg > max {
+			max = arg
+		}
+	}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(format) && strings.IndexByte("+-# 0", format[j]) > -1 {
+			j++
+		}
+		j = index(j)
+		if j < len(format) && format[j] == '*' {
+			consume()
+			j++
+		}
+		for j < len(format) && format[j] >= '0' && format[j] <= '9' {
+			j++
+		}
+		if j < len(format) && format[j] == '.' {
+			j = index(j + 1)
+			if j < len(format) && format[j] == '*' {
+				consume()
+				j++
+			}
+			for j < len(format) && format[j] >= '0' && format[j] <= '9' {
+				j++
+			}
+		}
+		j = index(j)
+		if j == len(format) {
+			break
+		}
+		consume()
+		_, n := utf8.DecodeRuneInString(format[j:])
+		i = j + n - 1
+	}
+	return max
+}
+
+func isVerbModifier(c byte) bool {
+	switch c {
+	case '+', '-', '#', ' ', '0', '.':
+		return true
+	}
+	return c >= '1' && c <= '9'
+}
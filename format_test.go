@@ -0,0 +1,152 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSprintf(t *testing.T) {
+	table := []struct {
+		Format string
+		Args   []interface{}
+	}{
+		{"Hello %s", []interface{}{"Ana"}},
+		{"Hola %s, tienes %d mensajes", []interface{}{"Ana", 3}},
+		{"%v items at %.2f%%", []interface{}{4, 9.5}},
+		{"%5d|%-5s|%05d", []interface{}{42, "ab", -7}},
+		{"%x %q %t", []interface{}{255, "q", true}},
+		{"error: %v", []interface{}{errors.New("boom")}},
+		{"%d", []interface{}{"not a number"}},
+		{"%s %s", []interface{}{"missing"}},
+		{"%s", []interface{}{"extra", "arg"}},
+		{"%[2]s %[1]s", []interface{}{"a", "b"}},
+		{"%*d", []interface{}{5, 3}},
+		{"trailing %", nil},
+		{"no verbs", nil},
+		{"100%% ñandú %s", []interface{}{"ok"}},
+		{"%ñ", []interface{}{1}},
+	}
+	for i := 0; i < 2; i++ {
+		for _, x := range table {
+			expected := fmt.Sprintf(x.Format, x.Args...)
+			if s := sprintf(x.Format, x.Args...); s != expected {
+				t.Errorf("%q expected %q, got %q", x.Format, expected, s)
+			}
+		}
+	}
+
+	cacheMut.RLock()
+	_, ok := fmtTemplates["Hello %s"]
+	cacheMut.RUnlock()
+	if !ok {
+		t.Fatalf("expected cached template")
+	}
+	mut.Lock()
+	invalidate()
+	mut.Unlock()
+	if len(fmtTemplates) != 0 {
+		t.Fatalf("expected templates cleared")
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "greet=Hello %s\ninbox=%s has %d messages\nfull=100%% done\ntitle=Inbox\n",
+		"es": "inbox=%[2]d mensajes de %[1]s\nwide=%*d|%-*.*f\n",
+	})
+	defer reset()
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+		Verbs    int
+		Found    bool
+	}{
+		{"en", "greet", "Hello %s", 1, true},
+		{"en", "inbox", "%s has %d messages", 2, true},
+		{"en", "full", "100%% done", 0, true},
+		{"en", "title", "Inbox", 0, true},
+		{"es", "inbox", "%[2]d mensajes de %[1]s", 2, true},
+		{"es", "wide", "%*d|%-*.*f", 5, true},
+		{"es", "greet", "Hello %s", 1, true},
+		{"en", "unknown", "", 0, false},
+	}
+	for _, x := range table {
+		s, verbs, ok := Template(x.Lang, x.Key)
+		if s != x.Expected || verbs != x.Verbs || ok != x.Found {
+			t.Errorf("%s:%s expected %q %d %v, got %q %d %v", x.Lang, x.Key, x.Expected, x.Verbs, x.Found, s, verbs, ok)
+		}
+	}
+	if n := countArgs("%[3]s %s"); n != 4 {
+		t.Fatalf("expected indexes to move the arg position, got %d", n)
+	}
+}
+
+func BenchmarkPrintfHot(b *testing.B) {
+	setup(b, "en", map[string]string{
+		"en": "inbox=Hello %s, you have %d new messages in %s\n",
+	})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Printf("en", "inbox", "Ana", 12, "Inbox")
+	}
+}
+
+func BenchmarkPrintfHotSprintf(b *testing.B) {
+	setup(b, "en", map[string]string{
+		"en": "inbox=Hello %s, you have %d new messages in %s\n",
+	})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mut.RLock()
+		v, _ := lookup("en", "inbox")
+		mut.RUnlock()
+		_ = fmt.Sprintf(v, "Ana", 12, "Inbox")
+	}
+}
+
+func TestAppendPrintf(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "inbox=Hello %s, you have %d new messages\nprice=%.2f EUR\nswap=%[2]s %[1]s\n",
+		"es": "inbox=Hola %s, tienes %d mensajes nuevos\n",
+	})
+	defer reset()
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Args     []interface{}
+		Expected string
+	}{
+		{"es", "inbox", []interface{}{"Ana", 3}, "Hola Ana, tienes 3 mensajes nuevos"},
+		{"es-MX", "price", []interface{}{9.5}, "9.50 EUR"},
+		{"en", "swap", []interface{}{"a", "b"}, "b a"},
+		{"en", "inbox", []interface{}{"Ana"}, "Hello Ana, you have %!d(MISSING) new messages"},
+		{"en", "unknown", []interface{}{1}, "unknown"},
+	}
+	b := []byte("> ")
+	for _, x := range table {
+		b = AppendPrintf(b[:2], x.Lang, x.Key, x.Args...)
+		if s := string(b); s != "> "+x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, "> "+x.Expected, s)
+		}
+		if s := Printf(x.Lang, x.Key, x.Args...); s != x.Expected {
+			t.Errorf("%s:%s expected Printf %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
+
+func BenchmarkAppendPrintf(b *testing.B) {
+	setup(b, "en", map[string]string{
+		"en": "inbox=Hello %s, you have %d new messages in %s\n",
+	})
+	buf := make([]byte, 0, 128)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = AppendPrintf(buf[:0], "en", "inbox", "Ana", 12, "Inbox")
+	}
+}
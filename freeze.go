@@ -0,0 +1,131 @@
+package i18n
+
+import "strings"
+
+// Catalog is an immutable snapshot of the catalog returned by Freeze. Its
+// methods don't lock, so it's cheaper than package funcs for services
+// loading once and never reloading. It's safe for concurrent use.
+type Catalog struct {
+	// values are resolved translations of loaded languages by lang and key.
+	values map[string]map[string]string
+	// defaults are resolved default language translations by key.
+	defaults map[string]string
+	prefix   string
+	resolver func(lang, key string) string
+	sep      *strings.Replacer
+	humanize bool
+}
+
+// Freeze returns a snapshot of the catalog as Println resolves it now,
+// later loads and settings don't change it. Values are resolved with the
+// fallback chain, aliases, references, PreferLatin and SetPipeline funcs
+// applied. Fetcher, deprecation warnings and fallback logging don't apply
+// to Catalog lookups.
+//
+// Values set with AddTranslationTTL are copied as they are served at freeze
+// time and don't expire in the snapshot, freeze again after expiry.
+func Freeze() *Catalog {
+	mut.RLock()
+	defer mut.RUnlock()
+	c := &Catalog{
+		values:   make(map[string]map[string]string),
+		defaults: make(map[string]string),
+		prefix:   keyPrefix,
+		resolver: keyResolver,
+		sep:      keySeparators,
+		humanize: humanizeMissing,
+	}
+	langSet := make(map[string]bool)
+	keySet := make(map[string]bool)
+	add := func(slug string) {
+		i := strings.Index(slug, ":")
+		langSet[slug[:i]] = true
+		keySet[slug[i+1:]] = true
+	}
+	for slug := range langs {
+		add(slug)
+	}
+	for slug := range base {
+		add(slug)
+	}
+	for slug := range timed {
+		add(slug)
+	}
+	for lang := range langSet {
+		c.values[lang] = make(map[string]string)
+	}
+	for key := range keySet {
+		for lang := range langSet {
+			if v, ok := frozenValue(lang, key); ok {
+				c.values[lang][key] = v
+			}
+		}
+		if def, ok := defaultLang(key); ok {
+			if v, ok := frozenValue(def, key); ok {
+				c.defaults[key] = v
+			}
+		}
+	}
+	return c
+}
+
+// frozenValue returns the value Println gets for normalized key. Caller
+// must hold mut.
+func frozenValue(lang, key string) (string, bool) {
+	if v, _, ok := latin(lang, key, fallbackLevels); ok {
+		return process(v), true
+	}
+	v, _, ok := resolve(lang, key)
+	if !ok {
+		return "", false
+	}
+	return process(v), true
+}
+
+// Println returns the translation of key for lang like package Println.
+func (c *Catalog) Println(lang, key string) string {
+	v, _ := c.lookup(lang, key)
+	return v
+}
+
+// Printf returns the translation of key for lang formatted with args like
+// package Printf.
+func (c *Catalog) Printf(lang, key string, args ...interface{}) string {
+	v, ok := c.lookup(lang, key)
+	if !ok {
+		return v
+	}
+	return sprintf(v, args...)
+}
+
+// lookup returns the translation for lang+key, false and the missing key
+// text if not found.
+func (c *Catalog) lookup(lang, key string) (string, bool) {
+	orig := key
+	if c.resolver != nil {
+		key = c.resolver(lang, key)
+	}
+	if c.prefix != "" && strings.HasPrefix(key, c.prefix) {
+		key = key[len(c.prefix):]
+	}
+	if c.sep != nil {
+		key = c.sep.Replace(key)
+	}
+
+	lang = cleanLang(lang)
+	if v, ok := c.values[lang][key]; ok {
+		return v, true
+	}
+	if len(lang) > 2 {
+		if v, ok := c.values[lang[:2]][key]; ok {
+			return v, true
+		}
+	}
+	if v, ok := c.defaults[key]; ok {
+		return v, true
+	}
+	if c.humanize {
+		return humanize(orig), false
+	}
+	return orig, false
+}
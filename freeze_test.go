@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "hello=Hello %s\nbye=Bye\nhome=Home\nsignup=@alias(home)\nfooter={@home} page\n",
+		"es":    "hello=Hola %s\nhome=Inicio\n",
+		"es-MX": "home=Casa\n",
+	})
+	defer reset()
+	SetPipeline(strings.TrimSpace)
+	c := Freeze()
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"es-MX", "home", "Casa"},
+		{"es-AR", "home", "Inicio"},
+		{"es-MX", "bye", "Bye"},
+		{"fr", "home", "Home"},
+		{"es", "signup", "Inicio"},
+		{"es", "footer", "Inicio page"},
+		{"en", "unknown", "unknown"},
+	}
+	check := func() {
+		for _, x := range table {
+			if s := c.Println(x.Lang, x.Key); s != x.Expected {
+				t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+			}
+			if s := Println(x.Lang, x.Key); x.Key != "unknown" && s == x.Expected {
+				t.Errorf("%s:%s expected package catalog changed", x.Lang, x.Key)
+			}
+		}
+		if s := c.Printf("es-MX", "hello", "Ana"); s != "Hola Ana" {
+			t.Errorf("expected Hola Ana, got %q", s)
+		}
+		cacheMut.RLock()
+		_, ok := fmtTemplates["Hola %s"]
+		cacheMut.RUnlock()
+		if !ok {
+			t.Errorf("expected frozen Printf format cached")
+		}
+	}
+
+	// mutations after Freeze don't change the snapshot.
+	setup(t, "fr", map[string]string{
+		"fr":    "hello=Salut %s\nbye=Salut\nhome=Accueil\nsignup=Inscription\nfooter=Pied\n",
+		"es":    "hello=Buenas %s\nhome=Hogar\nsignup=Registro\nfooter=Pie\nbye=Chao\n",
+		"es-MX": "home=Hogar\nbye=Chao\n",
+	})
+	check()
+}
+
+func benchmarkPrintln(b *testing.B, frozen bool) {
+	setup(b, "en", map[string]string{
+		"en":    "hello=Hello\nbye=Bye\n",
+		"es":    "hello=Hola\n",
+		"es-MX": "hello=Qué onda\n",
+	})
+	defer reset()
+	println := Println
+	if frozen {
+		println = Freeze().Println
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			println("es-MX", "hello")
+			println("es-AR", "bye")
+		}
+	})
+}
+
+func BenchmarkPrintlnLocked(b *testing.B) { benchmarkPrintln(b, false) }
+func BenchmarkPrintlnFrozen(b *testing.B) { benchmarkPrintln(b, true) }
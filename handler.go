@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Handler returns an http.Handler serving translations as JSON objects of
+// key and value, meant to be mounted at /i18n/:
+//
+//	http.Handle("/i18n/", i18n.Handler())
+//
+// GET /i18n/es-MX.json serves the best served language for es-MX (see
+// Negotiate) with fallback values merged, GET /i18n/ negotiates the
+// language from Accept-Language header, other paths get 404 Not Found.
+// Responses carry an ETag, requests with a matching If-None-Match get 304
+// Not Modified.
+func Handler() http.Handler {
+	return http.HandlerFunc(serveJSON)
+}
+
+func serveJSON(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var lang string
+	switch name := path.Base(r.URL.Path); {
+	case strings.HasSuffix(r.URL.Path, "/"):
+		lang = Negotiate(r.Header.Get("Accept-Language"))
+	case strings.HasSuffix(name, ".json") && name != ".json":
+		lang = Negotiate(strings.TrimSuffix(name, ".json"))
+	}
+	if lang == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	b, err := json.Marshal(Flatten(lang))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h := fnv.New64a()
+	h.Write(b)
+	etag := fmt.Sprintf(`"%x"`, h.Sum64())
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Vary", "Accept-Language")
+	w.Header().Set("Content-Language", lang)
+	if match := r.Header.Get("If-None-Match"); match != "" && strings.Contains(match, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(b)
+}
+
+// Flatten returns every key translated for lang or its fallback languages
+// with the value Println returns, base catalog and AddTranslationTTL keys
+// included.
+func Flatten(lang string) map[string]string {
+	mut.RLock()
+	defer mut.RUnlock()
+	m := make(map[string]string)
+	clean := cleanLang(lang)
+	add := func(slug string) {
+		i := strings.Index(slug, ":")
+		key := slug[i+1:]
+		if _, ok := m[key]; ok {
+			return
+		}
+		if l := slug[:i]; l != clean && !inChain(clean, l, key) {
+			return
+		}
+		if v, _, ok := find(clean, fallbackLevels, key); ok {
+			m[key] = v
+		}
+	}
+	for slug := range langs {
+		add(slug)
+	}
+	for slug := range base {
+		add(slug)
+	}
+	for slug := range timed {
+		add(slug)
+	}
+	return m
+}
+
+// inChain reports if l is in the fallback chain of lang for key. Caller
+// must hold mut.
+func inChain(lang, l, key string) bool {
+	for level := 1; level < fallbackLevels; level++ {
+		if fl, ok := fallback(lang, key, level); ok && fl == l {
+			return true
+		}
+	}
+	return false
+}
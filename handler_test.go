@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "home=Home\nbye=Bye\n",
+		"es":    "home=Inicio\n",
+		"es-MX": "hello=Qué onda\n",
+		"fr":    "home=Accueil\n",
+	})
+	defer reset()
+	SetBaseCatalog(map[string]map[string]string{
+		"en": {"lib.ok": "OK"},
+		"fr": {"lib.ok": "D'accord"},
+	})
+	srv := httptest.NewServer(Handler())
+	defer srv.Close()
+
+	get := func(path string, header map[string]string) *http.Response {
+		req, err := http.NewRequest("GET", srv.URL+path, nil)
+		if err != nil {
+			t.Fatalf("request: %s", err)
+		}
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("get %s: %s", path, err)
+		}
+		return res
+	}
+
+	table := []struct {
+		Path     string
+		Accept   string
+		Lang     string
+		Expected map[string]string
+	}{
+		{"/i18n/es-MX.json", "", "es-mx", map[string]string{"home": "Inicio", "bye": "Bye", "hello": "Qué onda", "lib.ok": "OK"}},
+		{"/i18n/es-AR.json", "", "es", map[string]string{"home": "Inicio", "bye": "Bye", "lib.ok": "OK"}},
+		{"/i18n/", "fr-CA,es;q=0.5", "fr", map[string]string{"home": "Accueil", "bye": "Bye", "lib.ok": "D'accord"}},
+		{"/i18n/de.json", "", "en", map[string]string{"home": "Home", "bye": "Bye", "lib.ok": "OK"}},
+	}
+	for _, x := range table {
+		res := get(x.Path, map[string]string{"Accept-Language": x.Accept})
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("%s expected 200, got %d", x.Path, res.StatusCode)
+		}
+		if s := res.Header.Get("Content-Type"); s != "application/json; charset=utf-8" {
+			t.Fatalf("%s unexpected content type %q", x.Path, s)
+		}
+		if s := res.Header.Get("Content-Language"); s != x.Lang {
+			t.Fatalf("%s expected lang %q, got %q", x.Path, x.Lang, s)
+		}
+		var m map[string]string
+		if err := json.NewDecoder(res.Body).Decode(&m); err != nil {
+			t.Fatalf("%s decode: %s", x.Path, err)
+		}
+		if len(m) != len(x.Expected) {
+			t.Fatalf("%s expected %v, got %v", x.Path, x.Expected, m)
+		}
+		for k, v := range x.Expected {
+			if m[k] != v {
+				t.Errorf("%s %s expected %q, got %q", x.Path, k, v, m[k])
+			}
+		}
+	}
+
+	for _, path := range []string{"/i18n/es", "/i18n/es.txt", "/i18n/.json"} {
+		res := get(path, nil)
+		res.Body.Close()
+		if res.StatusCode != http.StatusNotFound {
+			t.Fatalf("%s expected 404, got %d", path, res.StatusCode)
+		}
+	}
+
+	res := get("/i18n/es.json", nil)
+	res.Body.Close()
+	etag := res.Header.Get("ETag")
+	if etag == "" {
+		t.Fatalf("expected etag")
+	}
+	res = get("/i18n/es.json", map[string]string{"If-None-Match": etag})
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", res.StatusCode)
+	}
+	res = get("/i18n/fr.json", map[string]string{"If-None-Match": etag})
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 for other etag, got %d", res.StatusCode)
+	}
+
+	res, err := http.Post(srv.URL+"/i18n/es.json", "text/plain", nil)
+	if err != nil {
+		t.Fatalf("post: %s", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", res.StatusCode)
+	}
+}
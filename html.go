@@ -0,0 +1,19 @@
+package i18n
+
+import (
+	"html/template"
+	"strings"
+)
+
+// PrintlnBR returns translation HTML escaped with new lines converted to
+// <br> tags.
+func PrintlnBR(lang, key string) template.HTML {
+	return lineBreaks(Println(lang, key))
+}
+
+// lineBreaks returns s HTML escaped with new lines converted to <br> tags.
+func lineBreaks(s string) template.HTML {
+	s = template.HTMLEscapeString(s)
+	s = strings.Replace(s, "\r\n", "\n", -1)
+	return template.HTML(strings.Replace(s, "\n", "<br>", -1))
+}
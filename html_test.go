@@ -0,0 +1,30 @@
+package i18n
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+)
+
+func TestPrintlnBR(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "one=Single line\n",
+	})
+	mut.Lock()
+	langs[bullet("en", "para")] = "First <line>\nSecond & last\r\nThird"
+	mut.Unlock()
+
+	expected := template.HTML("First &lt;line&gt;<br>Second &amp; last<br>Third")
+	if s := PrintlnBR("en", "para"); s != expected {
+		t.Fatalf("expected %q, got %q", expected, s)
+	}
+
+	tmpl := template.Must(template.New("").Funcs(FuncMap).Parse(`<p>{{ i18nbr "en" "para" }}</p>`))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatalf("execute: %s", err)
+	}
+	if s := buf.String(); s != "<p>"+string(expected)+"</p>" {
+		t.Fatalf("unexpected output %q", s)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,9 +18,19 @@ var (
 	defLang string
 
 	// FuncMap contain all template funcs for integration with html templates.
+	//
+	// Funcs never panic, on an internal panic the key is returned and the
+	// panic logged, see SetLogger.
 	FuncMap = template.FuncMap{
-		"i18n":  Println,
-		"i18nf": Printf,
+		"i18n":      safePrintln,
+		"i18nf":     safePrintf,
+		"i18ndur":   safeDuration,
+		"i18nbr":    safeBR,
+		"i18ncount": safeCount,
+		"i18ncap":   safeCapitalize,
+		"i18nquote": safeQuote,
+		"i18nplain": safePlain,
+		"i18ntrunc": safeTruncate,
 	}
 	mut sync.RWMutex
 
@@ -26,72 +38,274 @@ var (
 )
 
 // Load reads files in directory (skipping subdirs) if file contains language data (KEY=VALUE)
+// Go source files (.go) like i18n-compile output are skipped.
 //
-// defaultLanguage is used if lang+key is not set.
+// defaultLanguage is used if lang+key is not set. If empty it's read from
+// a .default file in dir containing a language code, an explicit
+// defaultLanguage always wins.
 // separator if empty is (=), only first ocurrence in every line is taken.
 // comment symbol if empty is (#).
-func Load(dir, defaultLanguage, separator, comment string) error {
+// opts are optional load settings, see Option.
+//
+// A comment directive marks the next key as deprecated, Println and Printf
+// log a warning once per key when it's used (see SetLogger):
+//
+//	# @deprecated home.title
+//	home.heading=Home
+//
+// Another directive sets the attribution of the next key value, see
+// Attribution:
+//
+//	# @attribution=CC BY 4.0 Jane Doe
+//	poem.title=The Road
+func Load(dir, defaultLanguage, separator, comment string, opts ...Option) error {
+	_, err := LoadVerbose(dir, defaultLanguage, separator, comment, opts...)
+	return err
+}
+
+// LoadVerbose works like Load but returns warnings about skipped files,
+// malformed lines, empty values and duplicated keys. Those problems don't
+// stop loading, err is only returned for fatal errors like a missing
+// directory.
+func LoadVerbose(dir, defaultLanguage, separator, comment string, opts ...Option) ([]string, error) {
+	o := newOptions(opts)
+	m, err := parseDir(dir, separator, comment, o)
+	if err != nil {
+		return o.warnings, err
+	}
+	if err := o.rejected(); err != nil {
+		return o.warnings, err
+	}
+
+	if defaultLanguage == "" {
+		defaultLanguage = o.defLang
+	}
+
+	mut.Lock()
+	defer mut.Unlock()
 	defLang = defaultLanguage
+	if o.separators != nil {
+		keySeparators = o.separators
+	}
+	invalidate()
+	for slug, value := range m {
+		langs[slug] = value
+		sources[slug] = o.sources[slug]
+		if text, ok := o.attributions[slug]; ok {
+			attributions[slug] = text
+		} else {
+			delete(attributions, slug)
+		}
+	}
+	for key, replacement := range o.deprecated {
+		deprecated[key] = replacement
+	}
+	return o.warnings, o.err()
+}
+
+// Parse reads language files in dir like Load does returning values by
+// language and key, the catalog isn't modified.
+func Parse(dir, separator, comment string, opts ...Option) (map[string]map[string]string, error) {
+	o := newOptions(opts)
+	m, err := parseDir(dir, separator, comment, o)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.rejected(); err != nil {
+		return nil, err
+	}
+	catalog := make(map[string]map[string]string)
+	for slug, value := range m {
+		i := strings.Index(slug, ":")
+		lang, key := slug[:i], slug[i+1:]
+		if catalog[lang] == nil {
+			catalog[lang] = make(map[string]string)
+		}
+		catalog[lang][key] = value
+	}
+	return catalog, o.err()
+}
+
+// parseDir reads language files in dir returning values by lang:key.
+func parseDir(dir, separator, comment string, o *options) (map[string]string, error) {
 	if separator == "" {
 		separator = "="
 	}
 	if comment == "" {
 		comment = "#"
 	}
+	m := make(map[string]string)
 	err := filepath.Walk(dir, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
 		// skip directories
 		if info.IsDir() {
 			return nil
 		}
+		if info.Name() == defaultFile {
+			b, err := ioutil.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			o.defLang = strings.TrimSpace(string(b))
+			return nil
+		}
+		// skip Go sources like i18n-compile output.
+		if filepath.Ext(name) == ".go" {
+			return nil
+		}
 
 		// read language file
 		// must be format key=value
 		// file name is interpret it as language.
 		// it can be Language+Region like es-MX
-		lines, err := readLines(name, comment)
+		lines, err := readLines(name, comment, o.charset)
 		if err != nil {
 			return err
 		}
 
+		var valid int
+		directives := make(map[string]string)
 		for i := range lines {
-			line := lines[i]
+			line, source := lines[i].text, lines[i].path
 			// skip empty lines
 			if len(line) < 1 {
 				continue
 			}
-			key, value, err := processLine(line, separator)
+			if name, arg, ok := directive(line, comment); ok {
+				directives[name] = arg
+				continue
+			}
+			key, value, err := splitLine(line, separator, o.splitLast)
 			if err != nil {
 				// we don't return error here because .DS_Store file is created automatically
 				//
 				// if buggy we need a rule to skip files later.
+				o.warn("%s: malformed line %q", source, line)
 				continue
 			}
-			langs[bullet(info.Name(), key)] = value
+			if o.trimKey != "" {
+				key = strings.Trim(key, o.trimKey)
+			}
+			if o.separators != nil {
+				key = o.separators.Replace(key)
+			}
+			if o.namespace != "" {
+				key = o.namespace + "." + key
+			}
+			if o.escapes {
+				value = unescape(value)
+			}
+			valid++
+			slug := bullet(info.Name(), key)
+			if _, ok := m[slug]; ok {
+				o.warn("%s: duplicated key %q", source, key)
+			}
+			if value == "" {
+				o.warn("%s: empty value for key %q", source, key)
+			}
+			m[slug] = value
+			o.sources[slug] = source
+			if replacement, ok := directives["deprecated"]; ok {
+				o.deprecated[key] = replacement
+			}
+			if text, ok := directives["attribution"]; ok {
+				o.attributions[slug] = text
+			}
+			directives = make(map[string]string)
+			o.check(info.Name(), key, value)
+		}
+		if valid < 1 {
+			if o.rejectEmpty {
+				return fmt.Errorf("i18n: %s: no valid key/value pairs", name)
+			}
+			if len(lines) > 0 {
+				o.warn("%s: skipped file, no valid lines", name)
+			} else {
+				o.warn("%s: skipped file, empty", name)
+			}
 		}
 		return nil
 	})
-	return err
+	return m, err
+}
+
+// fileLine is a line and the path of the file it was read from.
+type fileLine struct {
+	text string
+	path string
+}
+
+// readLines returns non empty, non comment lines of path with includes
+// inlined.
+//
+// A line "@include other" is replaced with lines of file other, relative to
+// the including file directory. Included files inside the loaded directory
+// are loaded as languages too, keep them elsewhere.
+func readLines(path, commentSymbol, charset string) ([]fileLine, error) {
+	return readIncludes(path, commentSymbol, charset, nil)
 }
 
-func readLines(path, commentSymbol string) ([]string, error) {
+const includeDirective = "@include "
+
+// defaultFile contains the default language, see Load.
+const defaultFile = ".default"
+
+// readIncludes works like readLines, parents are the including files.
+func readIncludes(path, commentSymbol, charset string, parents []string) ([]fileLine, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for i := range parents {
+		if parents[i] == abs {
+			return nil, fmt.Errorf("i18n: include cycle: %s", strings.Join(append(parents, abs), " -> "))
+		}
+	}
+	parents = append(parents, abs)
+
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
 
-	var lines []string
-	scan := bufio.NewScanner(f)
+	var r io.Reader = f
+	if charset != "" {
+		r, err = decode(f, charset)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: %s: %s", path, err)
+		}
+	}
+
+	var lines []fileLine
+	scan := bufio.NewScanner(r)
 	for scan.Scan() {
 		line := scan.Text()
 		if len(line) < 1 {
 			continue
 		}
-		// skip comments
+		// skip comments, directives are kept for parseDir.
 		if line[:1] == commentSymbol {
+			if _, _, ok := directive(line, commentSymbol); ok {
+				lines = append(lines, fileLine{line, path})
+			}
 			continue
 		}
-		lines = append(lines, line)
+		if strings.HasPrefix(line, includeDirective) {
+			name := strings.TrimSpace(line[len(includeDirective):])
+			if !filepath.IsAbs(name) {
+				name = filepath.Join(filepath.Dir(path), name)
+			}
+			included, err := readIncludes(name, commentSymbol, charset, parents)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, included...)
+			continue
+		}
+		lines = append(lines, fileLine{line, path})
 	}
 	return lines, scan.Err()
 }
@@ -100,11 +314,20 @@ func readLines(path, commentSymbol string) ([]string, error) {
 //
 // If found more than 2 separators (=) takes only the first one.
 func processLine(s, separator string) (string, string, error) {
-	x := strings.Split(s, separator)
-	if len(x) < 2 {
+	return splitLine(s, separator, false)
+}
+
+// splitLine works like processLine splitting on the last separator if last
+// is true, see SplitOnLast.
+func splitLine(s, separator string, last bool) (string, string, error) {
+	i := strings.Index(s, separator)
+	if last {
+		i = strings.LastIndex(s, separator)
+	}
+	if i < 0 {
 		return "", "", errFormatNotValid
 	}
-	return x[0], s[len(x[0])+1:], nil
+	return s[:i], s[i+len(separator):], nil
 }
 
 // ReutilizeFuncMap takes a Template.FuncMap and adds methods of i18n returning it.
@@ -115,56 +338,194 @@ func ReutilizeFuncMap(fnmap template.FuncMap) template.FuncMap {
 	return fnmap
 }
 
+// FuncMapWithNames returns a copy of FuncMap with Println and Printf
+// registered as println and printf names instead of i18n and i18nf.
+//
+// Useful when templates already use i18n names for other funcs.
+func FuncMapWithNames(println, printf string) template.FuncMap {
+	fnmap := make(template.FuncMap, len(FuncMap))
+	for k, val := range FuncMap {
+		fnmap[k] = val
+	}
+	delete(fnmap, "i18n")
+	delete(fnmap, "i18nf")
+	fnmap[println] = safePrintln
+	fnmap[printf] = safePrintf
+	return fnmap
+}
+
 // Printf func
+//
+// Only the translation is used as format, on a miss the key is returned as
+// is. Keys must not be user controlled, see SanitizeKey.
 func Printf(lang, key string, args ...interface{}) string {
-	mut.RLock()
-	defer mut.RUnlock()
-	slug := bullet(lang, key)
-	k, ok := langs[slug]
+	v, ok := translate(lang, key)
 	if !ok {
-		// log.Printf("Printf : lang [%s] key [%s] not found", lang, key)
-		// try default language (first 2 digits)
-		// at this point lang length must be equal or greater than 2, so it's
-		// secure accesing it.
-		kl, ok := langs[bullet(lang[:2], key)]
-		if ok {
-			return fmt.Sprintf(kl, args...)
-		}
-
-		// try default language
-		kdef, ok := langs[bullet(defLang, key)]
-		if !ok {
-			return key
-		}
-		return fmt.Sprintf(kdef, args...)
+		return v
 	}
-	return fmt.Sprintf(k, args...)
+	return sprintf(v, args...)
 }
 
 // Println func
 func Println(lang, key string) string {
-	mut.RLock()
-	defer mut.RUnlock()
-	slug := bullet(lang, key)
-	k, ok := langs[slug]
+	v, _ := translate(lang, key)
+	return v
+}
+
+// SanitizeKey removes fmt verb markers (%) from key. Use it when a key is
+// built from user input, which is discouraged.
+func SanitizeKey(key string) string {
+	return strings.Replace(key, "%", "", -1)
+}
+
+// lookup returns the value Println serves for lang+key, see find. Caller
+// must hold mut.
+func lookup(lang, key string) (string, bool) {
+	v, _, ok := find(lang, fallbackLevels, key)
+	return v, ok
+}
+
+// find returns the value public lookups serve for the first of keys found
+// walking levels of the fallback chain, and the language serving it: keys
+// are normalized, deprecated keys warned, PreferLatin variants tried,
+// aliases followed, references expanded and the pipeline applied. Caller
+// must hold mut.
+func find(lang string, levels int, keys ...string) (string, string, bool) {
+	v, _, served, ok := findKey(lang, levels, keys...)
+	return v, served, ok
+}
+
+// findKey works like find returning the key of keys found too. Caller must
+// hold mut.
+func findKey(lang string, levels int, keys ...string) (string, string, string, bool) {
+	var v, key, served string
+	var ok bool
+	if len(keys) == 1 {
+		key = keys[0]
+		v, served, ok = findRaw(lang, levels, key)
+	} else {
+		v, key, served, ok = walkKeys(lang, levels, true, keys...)
+	}
+	if !ok {
+		return "", "", "", false
+	}
+	return process(v), key, served, true
+}
+
+// findRaw works like find skipping the pipeline. Caller must hold mut.
+func findRaw(lang string, levels int, keys ...string) (string, string, bool) {
+	if len(keys) != 1 {
+		v, _, served, ok := walkKeys(lang, levels, true, keys...)
+		return v, served, ok
+	}
+	key := normalizeKey(lang, keys[0])
+	warnDeprecated(key)
+	if v, served, ok := latin(lang, key, levels); ok {
+		return v, served, true
+	}
+	return resolveLevels(lang, key, levels)
+}
+
+// resolve returns the value for lang+key and the language serving it walking
+// the fallback chain, following aliases and expanding {@key} references.
+// Caller must hold mut.
+func resolve(lang, key string) (string, string, bool) {
+	return resolveLevels(lang, key, fallbackLevels)
+}
+
+// resolveLevels works like resolve walking only the first levels of the
+// fallback chain. Caller must hold mut.
+func resolveLevels(lang, key string, levels int) (string, string, bool) {
+	v, l, ok := resolveDepth(lang, key, levels)
 	if !ok {
-		// log.Printf("Println : lang [%s] key [%s] not found", lang, key)
-		// try default language (first 2 digits)
-		// at this point lang length must be equal or greater than 2, so it's
-		// secure accesing it.
-		kl, ok := langs[bullet(lang[:2], key)]
-		if ok {
-			return kl
+		return "", "", false
+	}
+	v, l, ok = follow(lang, v, l)
+	if !ok {
+		return "", "", false
+	}
+	return expandRefs(lang, v, 0), l, true
+}
+
+// resolveKey returns the value for lang+key and the language serving it
+// walking the fallback chain. Caller must hold mut.
+func resolveKey(lang, key string) (string, string, bool) {
+	return resolveDepth(lang, key, fallbackLevels)
+}
+
+// resolveDepth works like resolveKey walking only the first levels of the
+// fallback chain. Caller must hold mut.
+func resolveDepth(lang, key string, levels int) (string, string, bool) {
+	lang = cleanLang(lang)
+	if v, ok := value(lang + ":" + key); ok {
+		return v, lang, true
+	}
+
+	// full chain lookups use the fallback cache.
+	full := levels >= fallbackLevels
+	if full {
+		if served, ok := cachedFallback(lang + ":" + key); ok {
+			if served == "" {
+				return "", "", false
+			}
+			// served value may have expired, see AddTranslationTTL.
+			if v, ok := value(served + ":" + key); ok {
+				return v, served, true
+			}
+		}
+	}
+
+	var tried [fallbackLevels]string
+	tried[0] = lang
+	for level := 1; level < levels && level < fallbackLevels; level++ {
+		l, ok := fallback(lang, key, level)
+		if !ok || seen(tried[:level], l) {
+			continue
+		}
+		tried[level] = l
+		if v, ok := value(l + ":" + key); ok {
+			if full {
+				cacheFallback(lang+":"+key, l)
+			}
+			return v, l, true
 		}
+	}
+	if full {
+		cacheFallback(lang+":"+key, "")
+	}
+	return "", "", false
+}
+
+// fallbackLevels is the length of the fallback chain.
+const fallbackLevels = 3
+
+// fallback returns the clean language for level of the fallback chain: exact
+// language, language without region (first 2 digits) and default language.
+// It returns false if level doesn't apply to lang+key.
+// lang must be clean. Caller must hold mut.
+func fallback(lang, key string, level int) (string, bool) {
+	switch level {
+	case 0:
+		return lang, true
+	case 1:
+		if len(lang) <= 2 {
+			return "", false
+		}
+		return lang[:2], true
+	default:
+		l, ok := defaultLang(key)
+		return cleanLang(l), ok
+	}
+}
 
-		// try default language
-		kdef, ok := langs[bullet(defLang, key)]
-		if !ok {
-			return key
+// seen reports if lang is in list.
+func seen(list []string, lang string) bool {
+	for i := range list {
+		if list[i] == lang {
+			return true
 		}
-		return kdef
 	}
-	return k
+	return false
 }
 
 // bullet we need a format key for map of languages
@@ -1,8 +1,356 @@
 // Package i18n contains internationalization and location modules.
 package i18n
 
-import "testing"
+import (
+	"bytes"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
 
 func TestNew(t *testing.T) {
 
 }
+
+// setup resets package state and loads files (language -> content) as the
+// current catalog.
+func setup(t testing.TB, defaultLanguage string, files map[string]string) {
+	dir := writeFiles(t, files)
+	defer os.RemoveAll(dir)
+
+	reset()
+	if err := Load(dir, defaultLanguage, "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+}
+
+// reset clears catalog and package settings.
+func reset() {
+	mut.Lock()
+	defer mut.Unlock()
+	langs = make(map[string]string)
+	sources = make(map[string]string)
+	base = make(map[string]string)
+	defLang = ""
+	nsDefaults = make(map[string]string)
+	humanizeMissing = false
+	keyPrefix = ""
+	keyResolver = nil
+	keySeparators = nil
+	keyIndex = false
+	fetcher = nil
+	cacheFetched = false
+	customPluralRules = make(map[string]pluralRule)
+	served = nil
+	preview = false
+	trimPeriod = false
+	numberFormats = make(map[string][2]string)
+	deprecated = make(map[string]string)
+	deduped = make(map[string]bool)
+	attributions = make(map[string]string)
+	timed = make(map[string]timedEntry)
+	preferLatin = false
+	pipeline = nil
+	pendingEnabled = false
+	fallbackLog = LogNone
+	pending = nil
+	pendingSeen = make(map[string]bool)
+	invalidate()
+}
+
+// writeFiles writes files in a new temporary directory and returns its path.
+func writeFiles(t testing.TB, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "i18n")
+	if err != nil {
+		t.Fatalf("temp dir: %s", err)
+	}
+	for name, content := range files {
+		name = filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("mkdir: %s", err)
+		}
+		if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("write file: %s", err)
+		}
+	}
+	return dir
+}
+
+func TestPrintln(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "hello=Hello\nbye=Bye\n",
+		"es":    "hello=Hola\n",
+		"es-MX": "hello=Qué onda\n",
+	})
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"es-MX", "hello", "Qué onda"},
+		{"ES-mx", "hello", "Qué onda"},
+		{"es-AR", "hello", "Hola"},
+		{"es-AR", "bye", "Bye"},
+		{"e", "hello", "Hello"},
+		{"", "hello", "Hello"},
+		{"fr", "none", "none"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
+
+func benchmarkPrintf(b *testing.B, lang, key string) {
+	setup(b, "en", map[string]string{
+		"en":    "hello=Hello %s\nbye=Bye %s\n",
+		"es":    "hello=Hola %s\n",
+		"es-MX": "hello=Qué onda %s\n",
+	})
+	defer reset()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Printf(lang, key, "x")
+	}
+}
+
+func BenchmarkPrintfHit(b *testing.B)     { benchmarkPrintf(b, "es-MX", "hello") }
+func BenchmarkPrintfRegion(b *testing.B)  { benchmarkPrintf(b, "es-AR", "hello") }
+func BenchmarkPrintfDefault(b *testing.B) { benchmarkPrintf(b, "es-AR", "bye") }
+func BenchmarkPrintfMiss(b *testing.B)    { benchmarkPrintf(b, "es-AR", "none") }
+
+func TestLoadVerbose(t *testing.T) {
+	reset()
+	dir := writeFiles(t, map[string]string{
+		"en":        "hello=Hello\nbad line\nhello=Hello again\nempty=\n",
+		".DS_Store": "\x00\x01binary",
+	})
+	defer os.RemoveAll(dir)
+
+	warnings, err := LoadVerbose(dir, "en", "", "")
+	if err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	expected := []string{
+		filepath.Join(dir, ".DS_Store") + `: malformed line "\x00\x01binary"`,
+		filepath.Join(dir, ".DS_Store") + ": skipped file, no valid lines",
+		filepath.Join(dir, "en") + `: malformed line "bad line"`,
+		filepath.Join(dir, "en") + `: duplicated key "hello"`,
+		filepath.Join(dir, "en") + `: empty value for key "empty"`,
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expected warnings:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(warnings, "\n"))
+	}
+	if s := Println("en", "hello"); s != "Hello again" {
+		t.Fatalf("expected last value, got %q", s)
+	}
+
+	if _, err := LoadVerbose(filepath.Join(dir, "missing"), "en", "", ""); err == nil {
+		t.Fatalf("expected error for missing directory")
+	}
+}
+
+func TestFuncMapWithNames(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "hello=Hello\ngreet=Hello %s\n",
+		"es": "hello=Hola\ngreet=Hola %s\n",
+	})
+
+	fnmap := FuncMapWithNames("tr", "trf")
+	fnmap["i18n"] = func() string { return "other helper" }
+	tmpl, err := template.New("").Funcs(fnmap).Parse(`{{ tr .Lang "hello" }} {{ trf .Lang "greet" .Name }} {{ i18n }}`)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]string{"Lang": "es", "Name": "Ana"})
+	if err != nil {
+		t.Fatalf("execute: %s", err)
+	}
+	if s := buf.String(); s != "Hola Hola Ana other helper" {
+		t.Fatalf("unexpected output %q", s)
+	}
+	if _, ok := FuncMap["tr"]; ok {
+		t.Fatalf("expected FuncMap untouched")
+	}
+}
+
+func TestPrintfKeyNotFormatted(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "greet=Hello %s\n",
+	})
+
+	if s := Printf("en", "greet", "Ana"); s != "Hello Ana" {
+		t.Fatalf("unexpected value %q", s)
+	}
+	if s := Printf("en", "user.%s.%d", "evil", 1); s != "user.%s.%d" {
+		t.Fatalf("expected key returned literally, got %q", s)
+	}
+	if s := SanitizeKey("user.%s.%d%%"); s != "user.s.d" {
+		t.Fatalf("unexpected sanitized key %q", s)
+	}
+}
+
+func TestInclude(t *testing.T) {
+	reset()
+	root := writeFiles(t, map[string]string{
+		"locales/en":    "@include ../shared/common\nhome.title=Home\nbutton.ok=Okay\n",
+		"locales/es":    "@include ../shared/common-es\nhome.title=Inicio\n",
+		"shared/common": "button.ok=OK\n# comment\nbutton.cancel=Cancel\n",
+		// nested include.
+		"shared/common-es": "@include more/es\nbutton.ok=Aceptar\n",
+		"shared/more/es":   "button.cancel=Cancelar\n",
+	})
+	defer os.RemoveAll(root)
+
+	if err := Load(filepath.Join(root, "locales"), "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "button.ok", "Okay"},
+		{"en", "button.cancel", "Cancel"},
+		{"es", "button.ok", "Aceptar"},
+		{"es", "button.cancel", "Cancelar"},
+		{"es", "home.title", "Inicio"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+	if s := strings.Join(Languages(), ","); s != "en,es" {
+		t.Fatalf("unexpected languages %q", s)
+	}
+
+	cycle := writeFiles(t, map[string]string{
+		"locales/en": "@include ../shared/a\nhome.title=Home\n",
+		"shared/a":   "@include b\na=A\n",
+		"shared/b":   "@include a\nb=B\n",
+	})
+	defer os.RemoveAll(cycle)
+	err := Load(filepath.Join(cycle, "locales"), "en", "", "")
+	if err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Fatalf("expected include cycle error, got %v", err)
+	}
+
+	missing := writeFiles(t, map[string]string{
+		"en": "@include none\n",
+	})
+	defer os.RemoveAll(missing)
+	if err := Load(missing, "en", "", ""); err == nil {
+		t.Fatalf("expected error for missing include")
+	}
+}
+
+func BenchmarkPrintlnFallbackHeavy(b *testing.B) {
+	var en, es bytes.Buffer
+	keys := make([]string, 200)
+	for i := range keys {
+		keys[i] = "page.section.key" + strconv.Itoa(i)
+		en.WriteString(keys[i] + "=Value\n")
+		if i%2 == 0 {
+			es.WriteString(keys[i] + "=Valor\n")
+		}
+	}
+	setup(b, "en", map[string]string{"en": en.String(), "es": es.String()})
+	SetBaseCatalog(map[string]map[string]string{"en": {"lib.ok": "OK"}})
+	defer reset()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Println("es-AR", keys[i%len(keys)])
+	}
+}
+
+func TestDefaultFile(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		".default": "es\n",
+		"en":       "hello=Hello\nbye=Bye\n",
+		"es":       "hello=Hola\nonly=Solo\n",
+	})
+	defer os.RemoveAll(dir)
+
+	reset()
+	warnings, err := LoadVerbose(dir, "", "", "")
+	if err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if len(warnings) > 0 {
+		t.Fatalf("expected marker file not loaded, got %v", warnings)
+	}
+	if s := Println("fr", "only"); s != "Solo" {
+		t.Fatalf("expected es default from marker, got %q", s)
+	}
+	if list := Languages(); len(list) != 2 {
+		t.Fatalf("expected en and es languages, got %v", list)
+	}
+
+	// explicit default wins.
+	reset()
+	if err := Load(dir, "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if s := Println("fr", "hello"); s != "Hello" {
+		t.Fatalf("expected explicit en default, got %q", s)
+	}
+}
+
+func TestParse(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en":    "hello=Hello\nbye=Bye\n",
+		"es-MX": "hello=Qué onda\n",
+	})
+	defer os.RemoveAll(dir)
+
+	reset()
+	m, err := Parse(dir, "", "")
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if len(m) != 2 || m["en"]["bye"] != "Bye" || m["es-mx"]["hello"] != "Qué onda" {
+		t.Fatalf("unexpected catalog %v", m)
+	}
+	if len(langs) != 0 {
+		t.Fatalf("expected catalog untouched, got %v", langs)
+	}
+}
+
+func TestSplitLine(t *testing.T) {
+	table := []struct {
+		Line      string
+		Separator string
+		Last      bool
+		Key       string
+		Value     string
+	}{
+		{"a=b", "=", false, "a", "b"},
+		{"a=b=c", "=", false, "a", "b=c"},
+		{"a=b=c", "=", true, "a=b", "c"},
+		{"a::b::c", "::", false, "a", "b::c"},
+		{"a::b::c", "::", true, "a::b", "c"},
+		{"a=", "=", true, "a", ""},
+		{"=b", "=", false, "", "b"},
+	}
+	for _, x := range table {
+		key, value, err := splitLine(x.Line, x.Separator, x.Last)
+		if err != nil || key != x.Key || value != x.Value {
+			t.Errorf("%q last %v expected %q %q, got %q %q %v", x.Line, x.Last, x.Key, x.Value, key, value, err)
+		}
+	}
+	if _, _, err := processLine("no separator", "="); err != errFormatNotValid {
+		t.Fatalf("expected errFormatNotValid, got %v", err)
+	}
+}
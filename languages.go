@@ -0,0 +1,127 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Languages returns loaded languages sorted, only served languages if
+// SetServed was called.
+func Languages() []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	return servedLanguages()
+}
+
+// languages returns loaded languages sorted. Caller must hold mut.
+func languages() []string {
+	set := make(map[string]struct{})
+	for k := range langs {
+		set[k[:strings.Index(k, ":")]] = struct{}{}
+	}
+	list := make([]string, 0, len(set))
+	for lang := range set {
+		list = append(list, lang)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Keys returns sorted keys translated in lang. It doesn't follow the
+// fallback chain.
+func Keys(lang string) []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	return keys(lang)
+}
+
+// keys returns sorted keys of lang. Caller must hold mut.
+func keys(lang string) []string {
+	prefix := cleanLang(lang) + ":"
+	var list []string
+	for slug := range langs {
+		if strings.HasPrefix(slug, prefix) {
+			list = append(list, slug[len(prefix):])
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Grouped returns translations of keys of lang by namespace, the first
+// segment of dotted keys, and the rest of the key, e.g. settings.email.title
+// goes in group settings as email.title. Keys without dots go in group "".
+// Like Keys it doesn't follow the fallback chain for keys, values are
+// resolved as Println does.
+func Grouped(lang string) map[string]map[string]string {
+	mut.RLock()
+	defer mut.RUnlock()
+	groups := make(map[string]map[string]string)
+	for _, key := range keys(lang) {
+		ns, rest := "", key
+		if i := strings.Index(key, "."); i > -1 {
+			ns, rest = key[:i], key[i+1:]
+		}
+		v, _, _ := resolve(lang, key)
+		if groups[ns] == nil {
+			groups[ns] = make(map[string]string)
+		}
+		groups[ns][rest] = process(v)
+	}
+	return groups
+}
+
+// Values returns key value by language for languages translating key, e.g.
+// a row of a translation editor. It doesn't follow the fallback chain.
+func Values(key string) map[string]string {
+	mut.RLock()
+	defer mut.RUnlock()
+	suffix := ":" + key
+	m := make(map[string]string)
+	for slug, v := range langs {
+		if strings.HasSuffix(slug, suffix) && strings.Index(slug, ":") == len(slug)-len(suffix) {
+			m[slug[:len(slug)-len(suffix)]] = v
+		}
+	}
+	return m
+}
+
+// SupportedHeader returns served languages formatted as an Accept-Language
+// header value, e.g.: en,es;q=0.9,fr;q=0.8
+//
+// Default language goes first, then the rest sorted with q-values
+// descending by 0.1 down to 0.1.
+func SupportedHeader() string {
+	list, def := supported()
+
+	// move default language to the front.
+	for i := range list {
+		if list[i] == def {
+			copy(list[1:i+1], list[:i])
+			list[0] = def
+			break
+		}
+	}
+
+	s := make([]string, len(list))
+	for i := range list {
+		if i == 0 {
+			s[i] = list[i]
+			continue
+		}
+		q := 10 - i
+		if q < 1 {
+			q = 1
+		}
+		s[i] = fmt.Sprintf("%s;q=0.%d", list[i], q)
+	}
+	return strings.Join(s, ",")
+}
+
+// supported returns served languages and the clean default language.
+func supported() ([]string, string) {
+	mut.RLock()
+	defer mut.RUnlock()
+	return servedLanguages(), cleanLang(defLang)
+}
@@ -0,0 +1,115 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLanguages(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"fr":    "a=a\n",
+		"en":    "a=a\n",
+		"es-MX": "a=a\n",
+		"es":    "a=a\nb=b\n",
+	})
+	s := strings.Join(Languages(), ",")
+	if s != "en,es,es-mx,fr" {
+		t.Fatalf("unexpected languages %q", s)
+	}
+}
+
+func TestSupportedHeader(t *testing.T) {
+	setup(t, "es", map[string]string{
+		"fr": "a=a\n",
+		"en": "a=a\n",
+		"es": "a=a\n",
+	})
+	s := SupportedHeader()
+	if s != "es,en;q=0.9,fr;q=0.8" {
+		t.Fatalf("unexpected header %q", s)
+	}
+
+	setup(t, "en", map[string]string{
+		"a1": "a=a\n", "a2": "a=a\n", "a3": "a=a\n", "a4": "a=a\n",
+		"a5": "a=a\n", "a6": "a=a\n", "a7": "a=a\n", "a8": "a=a\n",
+		"a9": "a=a\n", "b1": "a=a\n", "b2": "a=a\n",
+	})
+	s = SupportedHeader()
+	if !strings.HasSuffix(s, "b1;q=0.1,b2;q=0.1") {
+		t.Fatalf("expected min q-value 0.1, got %q", s)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "b=b\na=a\n",
+		"es-MX": "c=c\n",
+	})
+	if s := strings.Join(Keys("en"), ","); s != "a,b" {
+		t.Fatalf("unexpected keys %q", s)
+	}
+	if s := strings.Join(Keys("ES-mx"), ","); s != "c" {
+		t.Fatalf("unexpected keys %q", s)
+	}
+	if s := strings.Join(Keys("es"), ","); s != "" {
+		t.Fatalf("unexpected keys %q", s)
+	}
+}
+
+func TestValues(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "home.title=Home\nmenu.home.title=Menu\n",
+		"es":    "home.title=Inicio\n",
+		"es-MX": "other=Otro\n",
+		"fr":    "home.title=Accueil\n",
+	})
+	m := Values("home.title")
+	expected := map[string]string{"en": "Home", "es": "Inicio", "fr": "Accueil"}
+	if len(m) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, m)
+	}
+	for lang, v := range expected {
+		if m[lang] != v {
+			t.Errorf("%s expected %q, got %q", lang, v, m[lang])
+		}
+	}
+	if m := Values("missing"); len(m) != 0 {
+		t.Fatalf("expected no values, got %v", m)
+	}
+}
+
+func TestGrouped(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "settings.email.title=Email\nsettings.email.hint=We never share it\nsettings.theme=Theme\n" +
+			"profile.name=Name\ntitle=Settings\nprofile.alias=@alias(settings.theme)\n",
+		"es": "settings.theme=Tema\n",
+	})
+	defer reset()
+
+	groups := Grouped("en")
+	table := []struct {
+		Group    string
+		Key      string
+		Expected string
+	}{
+		{"settings", "email.title", "Email"},
+		{"settings", "email.hint", "We never share it"},
+		{"settings", "theme", "Theme"},
+		{"profile", "name", "Name"},
+		{"profile", "alias", "Theme"},
+		{"", "title", "Settings"},
+	}
+	for _, x := range table {
+		if s := groups[x.Group][x.Key]; s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Group, x.Key, x.Expected, s)
+		}
+	}
+	if len(groups) != 3 || len(groups["settings"]) != 3 || len(groups["profile"]) != 2 {
+		t.Fatalf("unexpected groups %v", groups)
+	}
+
+	groups = Grouped("es")
+	if len(groups) != 1 || groups["settings"]["theme"] != "Tema" {
+		t.Fatalf("expected only es keys, got %v", groups)
+	}
+}
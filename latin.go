@@ -0,0 +1,30 @@
+package i18n
+
+// latinSuffix is the suffix of romanized variant keys.
+const latinSuffix = ".latn"
+
+// preferLatin enables romanized variants lookups, see PreferLatin.
+var preferLatin bool
+
+// PreferLatin makes lookups return the romanized variant key.latn of key
+// when it exists, e.g. for devices without fonts for a script:
+//
+//	greet=こんにちは
+//	greet.latn=Konnichiwa
+//
+// Keys without variant return the normal value. Disabled by default.
+func PreferLatin(enabled bool) {
+	mut.Lock()
+	defer mut.Unlock()
+	preferLatin = enabled
+}
+
+// latin returns the romanized variant of key for lang walking levels of the
+// fallback chain and the language serving it, if PreferLatin is enabled.
+// Caller must hold mut.
+func latin(lang, key string, levels int) (string, string, bool) {
+	if !preferLatin {
+		return "", "", false
+	}
+	return resolveLevels(lang, key+latinSuffix, levels)
+}
@@ -0,0 +1,35 @@
+package i18n
+
+import "testing"
+
+func TestPreferLatin(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "greet=Hello\nbye=Bye\n",
+		"ja": "greet=こんにちは\ngreet.latn=Konnichiwa\nbye=さようなら\n",
+		"ru": "greet=Привет %s\ngreet.latn=Privet %s\n",
+	})
+	defer reset()
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Latin    bool
+		Expected string
+	}{
+		{"ja", "greet", false, "こんにちは"},
+		{"ja", "greet", true, "Konnichiwa"},
+		{"ja", "bye", true, "さようなら"},
+		{"en", "greet", true, "Hello"},
+	}
+	for i := range table {
+		x := table[i]
+		PreferLatin(x.Latin)
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s latin %v expected %q, got %q", x.Lang, x.Key, x.Latin, x.Expected, s)
+		}
+	}
+	PreferLatin(true)
+	if s := Printf("ru", "greet", "Ana"); s != "Privet Ana" {
+		t.Fatalf("expected Privet Ana, got %q", s)
+	}
+}
@@ -0,0 +1,58 @@
+package i18n
+
+import "strings"
+
+// locale contains formatting data of a language built on first use.
+type locale struct {
+	plural pluralRule
+	// units are built-in duration words, nil if language has none.
+	units map[string][2]string
+	// grouping and decimal are number separators.
+	grouping string
+	decimal  string
+	// dateLayout is the built-in date layout, empty if language has none.
+	dateLayout string
+}
+
+// locales contains built locale data by clean language, cleared by
+// invalidate.
+var locales = make(map[string]*locale)
+
+// Warm builds formatting data (plural rules, duration words, number
+// separators, date layouts) for langs so first requests don't pay for it,
+// e.g. right after Load. Data is built again on use after the catalog,
+// plural rules or number formats change.
+func Warm(langs ...string) {
+	mut.RLock()
+	defer mut.RUnlock()
+	for i := range langs {
+		localeFor(langs[i])
+	}
+}
+
+// localeFor returns formatting data of lang. Caller must hold mut.
+func localeFor(lang string) *locale {
+	lang = cleanLang(lang)
+	cacheMut.RLock()
+	l, ok := locales[lang]
+	cacheMut.RUnlock()
+	if ok {
+		return l
+	}
+
+	l = &locale{
+		plural: pluralRuleFor(lang),
+	}
+	l.grouping, l.decimal = numberFormat(lang)
+	l.dateLayout = dateLayout(lang)
+	if words, ok := durationUnits[lang]; ok {
+		l.units = words
+	} else if i := strings.IndexAny(lang, "-_"); i > -1 {
+		l.units = durationUnits[lang[:i]]
+	}
+
+	cacheMut.Lock()
+	locales[lang] = l
+	cacheMut.Unlock()
+	return l
+}
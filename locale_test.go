@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWarm(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "a=a\n",
+	})
+	Warm("ru", "es-MX")
+
+	cacheMut.RLock()
+	ru, okRU := locales["ru"]
+	mx, okMX := locales["es-mx"]
+	cacheMut.RUnlock()
+	if !okRU || !okMX {
+		t.Fatalf("expected warmed locales, got %v", locales)
+	}
+	if c := ru.plural.category(3); c != PluralFew {
+		t.Fatalf("expected ru few, got %q", c)
+	}
+	if mx.units == nil || mx.units["hour"][1] != "%d horas" {
+		t.Fatalf("expected es duration words, got %v", mx.units)
+	}
+	if s := FormatDuration("es-MX", 2*time.Hour); s != "2 horas" {
+		t.Fatalf("expected 2 horas, got %q", s)
+	}
+
+	// catalog changes clear warmed data.
+	setup(t, "en", map[string]string{
+		"en": "a=a\n",
+	})
+	cacheMut.RLock()
+	n := len(locales)
+	cacheMut.RUnlock()
+	if n != 0 {
+		t.Fatalf("expected locales cleared, got %d", n)
+	}
+}
+
+func BenchmarkFormatDurationCold(b *testing.B) {
+	setup(b, "en", map[string]string{
+		"en": "a=a\n",
+	})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mut.Lock()
+		invalidate()
+		mut.Unlock()
+		FormatDuration("es-MX", 90*time.Minute)
+	}
+}
+
+func BenchmarkFormatDurationWarm(b *testing.B) {
+	setup(b, "en", map[string]string{
+		"en": "a=a\n",
+	})
+	Warm("es-MX")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FormatDuration("es-MX", 90*time.Minute)
+	}
+}
@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"strconv"
+	"time"
+)
+
+// PrintfLocalized works like Printf formatting args with lang conventions
+// first. Localized args are:
+//
+//	float32, float64 with %v, %s or %.Nf: FormatNumber separators
+//	int, int8..int64, uint..uint64 with %v, %s or %d: FormatNumber grouping
+//	time.Time with %v or %s: FormatDate
+//	time.Duration with %v or %s: FormatDuration
+//
+// Other args and verbs (flags, widths, explicit indexes) are formatted by
+// fmt as Printf does.
+func PrintfLocalized(lang, key string, args ...interface{}) string {
+	v, ok := translate(lang, key)
+	if !ok {
+		return v
+	}
+	mut.RLock()
+	defer mut.RUnlock()
+	t := templateFor(v)
+	if !t.ok || len(t.verbs) != len(args) {
+		return sprintf(v, args...)
+	}
+
+	b := make([]byte, 0, t.size+8*len(args))
+	for i := range t.verbs {
+		b = append(b, t.literals[i]...)
+		if s, ok := localizeArg(lang, t.verbs[i], args[i]); ok {
+			b = append(b, s...)
+			continue
+		}
+		b = appendArg(b, t.verbs[i], args[i])
+	}
+	b = append(b, t.literals[len(t.verbs)]...)
+	return string(b)
+}
+
+// localizeArg returns arg formatted for lang, false if arg or verb aren't
+// localized. Caller must hold mut.
+func localizeArg(lang, verb string, arg interface{}) (string, bool) {
+	plain := verb == "%v" || verb == "%s"
+	switch arg := arg.(type) {
+	case time.Time:
+		if plain {
+			return formatDate(lang, arg), true
+		}
+	case time.Duration:
+		if plain {
+			return formatDuration(lang, arg), true
+		}
+	case float64:
+		return localizeFloat(lang, verb, arg)
+	case float32:
+		return localizeFloat(lang, verb, float64(arg))
+	}
+	if s, ok := formatInt(arg); ok && (plain || verb == "%d") {
+		return formatInteger(lang, s), true
+	}
+	return "", false
+}
+
+// localizeFloat formats v with %v, %s or %.Nf verb. Caller must hold mut.
+func localizeFloat(lang, verb string, v float64) (string, bool) {
+	if verb == "%v" || verb == "%s" {
+		return formatNumber(lang, v, -1), true
+	}
+	if len(verb) > 3 && verb[:2] == "%." && verb[len(verb)-1] == 'f' {
+		if n, err := strconv.Atoi(verb[2 : len(verb)-1]); err == nil {
+			return formatNumber(lang, v, n), true
+		}
+	}
+	return "", false
+}
+
+// formatInt returns integer arg in base 10, without float conversion so
+// values above 2^53 keep their digits.
+func formatInt(arg interface{}) (string, bool) {
+	switch n := arg.(type) {
+	case int:
+		return strconv.FormatInt(int64(n), 10), true
+	case int8:
+		return strconv.FormatInt(int64(n), 10), true
+	case int16:
+		return strconv.FormatInt(int64(n), 10), true
+	case int32:
+		return strconv.FormatInt(int64(n), 10), true
+	case int64:
+		return strconv.FormatInt(n, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint8:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint16:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint64:
+		return strconv.FormatUint(n, 10), true
+	}
+	return "", false
+}
@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPrintfLocalized(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "total=Total %v on %v\nprice=%.2f EUR\ncount=%d items\nwait=Wait %v\n" +
+			"padded=%8.2f|%5d\nname=Hi %s\n",
+		"es": "total=Total %v el %v\n",
+	})
+	d := time.Date(2017, 12, 31, 10, 0, 0, 0, time.UTC)
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Args     []interface{}
+		Expected string
+	}{
+		{"en", "total", []interface{}{1234.5, d}, "Total 1,234.5 on 12/31/2017"},
+		{"es", "total", []interface{}{1234.5, d}, "Total 1.234,5 el 31/12/2017"},
+		{"es", "price", []interface{}{float32(1234.567)}, "1.234,57 EUR"},
+		{"es", "count", []interface{}{int64(1000000)}, "1.000.000 items"},
+		{"en", "count", []interface{}{int64(9007199254740993)}, "9,007,199,254,740,993 items"},
+		{"en", "count", []interface{}{uint64(18446744073709551615)}, "18,446,744,073,709,551,615 items"},
+		{"es", "count", []interface{}{-1234}, "-1.234 items"},
+		{"es", "wait", []interface{}{90 * time.Minute}, "Wait 1 hora 30 minutos"},
+		{"es", "padded", []interface{}{1.5, 42}, "    1.50|   42"},
+		{"es", "name", []interface{}{"Ana"}, "Hi Ana"},
+		{"es", "total", []interface{}{1.5}, "Total 1.5 el %!v(MISSING)"},
+		{"es", "none", nil, "none"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PrintfLocalized(x.Lang, x.Key, x.Args...); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
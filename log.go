@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"log"
+	"sync"
+)
+
+var (
+	// logger receives package warnings, nil means log package standard
+	// logger.
+	logger  *log.Logger
+	logMut  sync.Mutex
+	deduped = make(map[string]bool)
+)
+
+// SetLogger sets l to receive warnings, e.g. deprecated keys use. nil
+// restores the log package standard logger.
+func SetLogger(l *log.Logger) {
+	logMut.Lock()
+	defer logMut.Unlock()
+	logger = l
+}
+
+// logOnce logs message once per id.
+func logOnce(id, format string, args ...interface{}) {
+	logMut.Lock()
+	defer logMut.Unlock()
+	if deduped[id] {
+		return
+	}
+	deduped[id] = true
+	output(format, args...)
+}
+
+// logf logs message.
+func logf(format string, args ...interface{}) {
+	logMut.Lock()
+	defer logMut.Unlock()
+	output(format, args...)
+}
+
+// output writes message to logger. Caller must hold logMut.
+func output(format string, args ...interface{}) {
+	if logger == nil {
+		log.Printf(format, args...)
+		return
+	}
+	logger.Printf(format, args...)
+}
@@ -0,0 +1,145 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PrintlnFallbackKey returns translation for key, if lang doesn't contain
+// key it tries fallbackKey in lang before moving to the next language of the
+// fallback chain.
+//
+// Useful while renaming keys.
+func PrintlnFallbackKey(lang, key, fallbackKey string) string {
+	v, _, _ := translateKeys(lang, fallbackLevels, key, key, fallbackKey)
+	return v
+}
+
+// PrintlnVariantKey returns translation for key+"."+variant, if lang doesn't
+// contain it falls back to key before moving to the next language of the
+// fallback chain.
+//
+// Useful for copy experiments: home.title.b=... overrides home.title for
+// users in variant b.
+func PrintlnVariantKey(lang, key, variant string) string {
+	if variant == "" {
+		return Println(lang, key)
+	}
+	v, _, _ := translateKeys(lang, fallbackLevels, key, key+"."+variant, key)
+	return v
+}
+
+// PrintlnID returns translation for a numeric message id, e.g. id 1001 for
+// line 1001=Welcome
+func PrintlnID(lang string, id int) string {
+	return Println(lang, strconv.Itoa(id))
+}
+
+// PrintlnParts returns translation for the key built joining parts with
+// dots, empty parts are skipped:
+//
+//	PrintlnParts(lang, "menu", "file", "open") // menu.file.open
+func PrintlnParts(lang string, parts ...string) string {
+	list := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p != "" {
+			list = append(list, p)
+		}
+	}
+	return Println(lang, strings.Join(list, "."))
+}
+
+// Resolve returns the translation for lang+key and the language serving
+// it, false if not found.
+func Resolve(lang, key string) (string, string, bool) {
+	mut.RLock()
+	defer mut.RUnlock()
+	return find(lang, fallbackLevels, key)
+}
+
+// PrintlnDepth works like Println walking only maxDepth fallback levels:
+// 0 exact language only, 1 adds the base language of a region (es for
+// es-MX), 2 adds the default language. Useful to debug the fallback chain
+// level by level.
+func PrintlnDepth(lang, key string, maxDepth int) string {
+	if maxDepth < 0 {
+		maxDepth = 0
+	}
+	if maxDepth >= fallbackLevels {
+		maxDepth = fallbackLevels - 1
+	}
+	v, _, _ := translateKeys(lang, maxDepth+1, key)
+	return v
+}
+
+// Result is a translation with its metadata, see Get.
+type Result struct {
+	// Value is the translation, on a miss what Println returns.
+	Value string
+	// Lang is the language serving Value, empty on a miss.
+	Lang string
+	// Dir is the text direction of Value: rtl or ltr.
+	Dir   string
+	Found bool
+}
+
+// Get returns the translation for lang+key together with its serving
+// language and direction. On a miss Dir is lang direction.
+func Get(lang, key string) Result {
+	v, served, ok := translateKeys(lang, fallbackLevels, key)
+	if !ok {
+		return Result{Value: v, Dir: Direction(lang)}
+	}
+	return Result{Value: v, Lang: served, Dir: Direction(served), Found: true}
+}
+
+// RenderAll returns translations of keys for lang as Println does. Useful
+// to snapshot and compare output across reloads.
+func RenderAll(lang string, keys []string) map[string]string {
+	m := make(map[string]string, len(keys))
+	for _, key := range keys {
+		m[key] = Println(lang, key)
+	}
+	return m
+}
+
+// lookupKeys walks the fallback chain trying every key on each language,
+// values like date layouts skip find handling. Caller must hold mut.
+func lookupKeys(lang string, keys ...string) (string, bool) {
+	v, _, _, ok := walkKeys(lang, fallbackLevels, false, keys...)
+	return v, ok
+}
+
+// walkKeys walks levels of the fallback chain trying every key on each
+// language, returning the value found, its key as given and serving
+// language. For
+// public lookups PreferLatin variants go first and deprecated keys are
+// warned, see find. Caller must hold mut.
+func walkKeys(lang string, levels int, public bool, keys ...string) (string, string, string, bool) {
+	lang = cleanLang(lang)
+	for level := 0; level < levels && level < fallbackLevels; level++ {
+		for i := range keys {
+			key := normalizeKey(lang, keys[i])
+			l, ok := fallback(lang, key, level)
+			if !ok {
+				continue
+			}
+			candidates := []string{key}
+			if public && preferLatin {
+				candidates = []string{key + latinSuffix, key}
+			}
+			for _, k := range candidates {
+				v, ok := value(l + ":" + k)
+				if !ok {
+					continue
+				}
+				if public {
+					warnDeprecated(key)
+				}
+				v, l, ok = follow(lang, v, l)
+				return expandRefs(lang, v, 0), keys[i], l, ok
+			}
+		}
+	}
+	return "", "", "", false
+}
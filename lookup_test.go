@@ -0,0 +1,215 @@
+package i18n
+
+import "testing"
+
+func TestPrintlnFallbackKey(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "login.submit=Sign in\nlogin.button=Log in\n",
+		"es": "login.button=Entrar\n",
+	})
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Fallback string
+		Expected string
+	}{
+		{"en", "login.submit", "login.button", "Sign in"},
+		{"es", "login.submit", "login.button", "Entrar"},
+		{"es-MX", "login.submit", "login.button", "Entrar"},
+		{"fr", "login.submit", "login.button", "Sign in"},
+		{"es", "none", "other", "none"},
+	}
+	for i := range table {
+		x := table[i]
+		s := PrintlnFallbackKey(x.Lang, x.Key, x.Fallback)
+		if s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
+
+func TestPrintlnVariantKey(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Welcome\nhome.title.b=Welcome back\n",
+		"es": "home.title=Bienvenido\n",
+	})
+
+	table := []struct {
+		Lang     string
+		Variant  string
+		Expected string
+	}{
+		{"en", "b", "Welcome back"},
+		{"en", "c", "Welcome"},
+		{"en", "", "Welcome"},
+		{"es", "b", "Bienvenido"},
+		{"fr", "b", "Welcome back"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PrintlnVariantKey(x.Lang, "home.title", x.Variant); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Variant, x.Expected, s)
+		}
+	}
+	if s := PrintlnVariantKey("en", "none", "b"); s != "none" {
+		t.Fatalf("expected key on miss, got %q", s)
+	}
+}
+
+func TestPrintlnID(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "1001=Welcome\n1002=Bye\n",
+		"es": "1001=Bienvenido\n",
+	})
+
+	table := []struct {
+		Lang     string
+		ID       int
+		Expected string
+	}{
+		{"es", 1001, "Bienvenido"},
+		{"es", 1002, "Bye"},
+		{"es", 9999, "9999"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PrintlnID(x.Lang, x.ID); s != x.Expected {
+			t.Errorf("%s:%d expected %q, got %q", x.Lang, x.ID, x.Expected, s)
+		}
+	}
+}
+
+func TestPrintlnParts(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "menu.file.open=Open\nmenu=Menu\n",
+		"es": "menu.file.open=Abrir\n",
+	})
+
+	table := []struct {
+		Parts    []string
+		Expected string
+	}{
+		{[]string{"menu", "file", "open"}, "Abrir"},
+		{[]string{"menu", "", "file", "", "open"}, "Abrir"},
+		{[]string{"", "menu"}, "Menu"},
+		{[]string{"menu", "file"}, "menu.file"},
+		{nil, ""},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PrintlnParts("es", x.Parts...); s != x.Expected {
+			t.Errorf("%v expected %q, got %q", x.Parts, x.Expected, s)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home=Home\nbye=Bye\n",
+		"es": "home=Inicio\n",
+	})
+
+	table := []struct {
+		Lang   string
+		Key    string
+		Value  string
+		Served string
+		Found  bool
+	}{
+		{"es-MX", "home", "Inicio", "es", true},
+		{"es", "bye", "Bye", "en", true},
+		{"en", "home", "Home", "en", true},
+		{"es", "none", "", "", false},
+	}
+	for i := range table {
+		x := table[i]
+		v, served, ok := Resolve(x.Lang, x.Key)
+		if v != x.Value || served != x.Served || ok != x.Found {
+			t.Errorf("%s:%s expected %q %q %v, got %q %q %v", x.Lang, x.Key, x.Value, x.Served, x.Found, v, served, ok)
+		}
+	}
+}
+
+func TestRenderAll(t *testing.T) {
+	files := map[string]string{
+		"en": "home=Home\nbye=Bye\n",
+		"es": "home=Inicio\n",
+	}
+	setup(t, "en", files)
+	keys := []string{"home", "bye", "none"}
+	before := RenderAll("es", keys)
+	if before["home"] != "Inicio" || before["bye"] != "Bye" || before["none"] != "none" {
+		t.Fatalf("unexpected render %v", before)
+	}
+
+	// same files same output.
+	setup(t, "en", files)
+	after := RenderAll("es", keys)
+	for _, key := range keys {
+		if before[key] != after[key] {
+			t.Fatalf("key %s changed across reloads: %q -> %q", key, before[key], after[key])
+		}
+	}
+
+	files["es"] = "home=Inicio\nbye=Adiós\n"
+	setup(t, "en", files)
+	after = RenderAll("es", keys)
+	if before["bye"] == after["bye"] || before["home"] != after["home"] {
+		t.Fatalf("expected only bye to change, got %v -> %v", before, after)
+	}
+}
+
+func TestGet(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home=Home\nbye=Bye\n",
+		"ar": "home=الرئيسية\n",
+	})
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected Result
+	}{
+		{"ar-EG", "home", Result{"الرئيسية", "ar", "rtl", true}},
+		{"ar", "bye", Result{"Bye", "en", "ltr", true}},
+		{"en", "home", Result{"Home", "en", "ltr", true}},
+		{"ar", "none", Result{"none", "", "rtl", false}},
+	}
+	for i := range table {
+		x := table[i]
+		if r := Get(x.Lang, x.Key); r != x.Expected {
+			t.Errorf("%s:%s expected %+v, got %+v", x.Lang, x.Key, x.Expected, r)
+		}
+	}
+}
+
+func TestPrintlnDepth(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "home=Home\nbye=Bye\nhello=Hello\n",
+		"es":    "home=Inicio\nbye=Adiós\n",
+		"es-MX": "home=Inicio MX\n",
+	})
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Depth    int
+		Expected string
+	}{
+		{"es-MX", "home", 0, "Inicio MX"},
+		{"es-MX", "bye", 0, "bye"},
+		{"es-MX", "bye", 1, "Adiós"},
+		{"es-MX", "hello", 1, "hello"},
+		{"es-MX", "hello", 2, "Hello"},
+		{"es-MX", "hello", 10, "Hello"},
+		{"es", "hello", 1, "hello"},
+		{"es", "hello", -1, "hello"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PrintlnDepth(x.Lang, x.Key, x.Depth); s != x.Expected {
+			t.Errorf("%s:%s depth %d expected %q, got %q", x.Lang, x.Key, x.Depth, x.Expected, s)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// messageSyntax matches ICU message arguments like {count, plural, ...}
+var messageSyntax = regexp.MustCompile(`\{\s*\w+\s*,\s*(plural|select|selectordinal)\s*,`)
+
+// MessageKeys returns sorted keys whose value in any language uses ICU
+// message syntax: {arg, plural, ...}, {arg, select, ...} or
+// {arg, selectordinal, ...}
+func MessageKeys() []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	set := make(map[string]struct{})
+	for slug, value := range langs {
+		if !strings.Contains(value, "{") || !messageSyntax.MatchString(value) {
+			continue
+		}
+		set[slug[strings.Index(slug, ":")+1:]] = struct{}{}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// PrintfNamed returns translation for lang+key replacing named placeholders
+// {name} with args values. Placeholders can declare a default used when the
+// arg is missing: {name=Guest}
+//
+// Placeholders without arg nor default are left untouched.
+func PrintfNamed(lang, key string, args map[string]interface{}) string {
+	v, ok := translate(lang, key)
+	if !ok {
+		return v
+	}
+	return replaceNamed(v, args)
+}
+
+// replaceNamed replaces {name} and {name=default} placeholders in s.
+func replaceNamed(s string, args map[string]interface{}) string {
+	if !strings.Contains(s, "{") {
+		return s
+	}
+	var buf bytes.Buffer
+	for {
+		i := strings.Index(s, "{")
+		if i < 0 {
+			break
+		}
+		name, def, hasDef, n := placeholder(s[i:])
+		if n < 1 {
+			buf.WriteString(s[:i+1])
+			s = s[i+1:]
+			continue
+		}
+		buf.WriteString(s[:i])
+		if arg, ok := args[name]; ok {
+			fmt.Fprint(&buf, arg)
+		} else if hasDef {
+			buf.WriteString(def)
+		} else {
+			buf.WriteString(s[i : i+n])
+		}
+		s = s[i+n:]
+	}
+	buf.WriteString(s)
+	return buf.String()
+}
+
+// placeholder parses a named placeholder at the start of s returning its
+// name, default value and length. Length is zero if s doesn't start with a
+// placeholder.
+func placeholder(s string) (string, string, bool, int) {
+	end := strings.Index(s, "}")
+	if end < 0 {
+		return "", "", false, 0
+	}
+	body := s[1:end]
+	name, def, hasDef := body, "", false
+	if i := strings.Index(body, "="); i > -1 {
+		name, def, hasDef = body[:i], body[i+1:], true
+	}
+	if name == "" {
+		return "", "", false, 0
+	}
+	for _, r := range name {
+		if !(r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return "", "", false, 0
+		}
+	}
+	return name, def, hasDef, end + 1
+}
@@ -0,0 +1,50 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageKeys(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "plain=Hello {name}\n" +
+			"inbox={count, plural, one {# message} other {# messages}}\n" +
+			"fmt=Hello %s\n" +
+			"braces={not message}\n",
+		"es": "invite={gender,select,female {Invitada} other {Invitado}}\n" +
+			"rank=Quedaste {pos, selectordinal, one {#ro} other {#to}}\n" +
+			"plain=Hola {name}\n",
+	})
+
+	s := strings.Join(MessageKeys(), ",")
+	if s != "inbox,invite,rank" {
+		t.Fatalf("unexpected message keys %q", s)
+	}
+}
+
+func TestPrintfNamed(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "welcome=Hello {name=Guest}, you have {count} {unit=messages}\n" +
+			"icu={count, plural, one {# item} other {# items}}\n" +
+			"empty=Hi {name=}!\n",
+	})
+
+	table := []struct {
+		Key      string
+		Args     map[string]interface{}
+		Expected string
+	}{
+		{"welcome", map[string]interface{}{"name": "Ana", "count": 3, "unit": "alerts"}, "Hello Ana, you have 3 alerts"},
+		{"welcome", map[string]interface{}{"count": 1}, "Hello Guest, you have 1 messages"},
+		{"welcome", nil, "Hello Guest, you have {count} messages"},
+		{"icu", map[string]interface{}{"count": 1}, "{count, plural, one {# item} other {# items}}"},
+		{"empty", nil, "Hi !"},
+		{"none", nil, "none"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PrintfNamed("en", x.Key, x.Args); s != x.Expected {
+			t.Errorf("%s expected %q, got %q", x.Key, x.Expected, s)
+		}
+	}
+}
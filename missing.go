@@ -0,0 +1,74 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// humanizeMissing enables key humanization on misses.
+var humanizeMissing bool
+
+// SetMissingHumanizer enables returning a humanized version of the last key
+// segment instead of the raw key when a translation is missing:
+//
+//	home.title      -> Title
+//	home.page_title -> Page Title
+//
+// Disabled by default.
+func SetMissingHumanizer(enabled bool) {
+	mut.Lock()
+	defer mut.Unlock()
+	humanizeMissing = enabled
+}
+
+// ErrMissing is returned when no translation exists for Lang and Key, not
+// even in fallback languages.
+type ErrMissing struct {
+	Lang string
+	Key  string
+}
+
+func (e *ErrMissing) Error() string {
+	return fmt.Sprintf("i18n: missing translation lang [%s] key [%s]", e.Lang, e.Key)
+}
+
+// PrintlnE works like Println but returns an *ErrMissing error instead of
+// the key when the translation is missing.
+func PrintlnE(lang, key string) (string, error) {
+	mut.RLock()
+	defer mut.RUnlock()
+	v, ok := lookup(lang, key)
+	if !ok {
+		return "", &ErrMissing{Lang: lang, Key: key}
+	}
+	return v, nil
+}
+
+// missing returns the value for a missing key. Caller must hold mut.
+func missing(key string) string {
+	if humanizeMissing {
+		return humanize(key)
+	}
+	return key
+}
+
+// humanize returns last segment of key title cased, using underscores and
+// hyphens as word separators.
+func humanize(key string) string {
+	if i := strings.LastIndex(key, "."); i > -1 && i < len(key)-1 {
+		key = key[i+1:]
+	}
+	words := strings.FieldsFunc(key, func(r rune) bool {
+		return r == '_' || r == '-' || unicode.IsSpace(r)
+	})
+	for i, w := range words {
+		r, n := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[n:]
+	}
+	if len(words) < 1 {
+		return key
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import "testing"
+
+func TestSetMissingHumanizer(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Home\n",
+	})
+	defer reset()
+
+	if s := Println("en", "home.subtitle"); s != "home.subtitle" {
+		t.Fatalf("expected raw key by default, got %q", s)
+	}
+
+	SetMissingHumanizer(true)
+	table := []struct {
+		Key      string
+		Expected string
+	}{
+		{"home.title", "Home"},
+		{"home.subtitle", "Subtitle"},
+		{"home.page_title", "Page Title"},
+		{"user_first_name", "User First Name"},
+		{"menu.file-open", "File Open"},
+		{"menu.", "Menu."},
+		{"ñandú", "Ñandú"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println("en", x.Key); s != x.Expected {
+			t.Errorf("%s expected %q, got %q", x.Key, x.Expected, s)
+		}
+	}
+	if s := Printf("en", "home.count_%d", 1); s != "Count %d" {
+		t.Fatalf("expected humanized key, got %q", s)
+	}
+}
+
+func TestPrintlnE(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Home\n",
+		"es": "home.title=Inicio\n",
+	})
+
+	s, err := PrintlnE("es-MX", "home.title")
+	if err != nil || s != "Inicio" {
+		t.Fatalf("expected fallback translation, got %q %v", s, err)
+	}
+	s, err = PrintlnE("fr", "home.title")
+	if err != nil || s != "Home" {
+		t.Fatalf("expected default translation, got %q %v", s, err)
+	}
+
+	s, err = PrintlnE("es", "home.subtitle")
+	e, ok := err.(*ErrMissing)
+	if !ok {
+		t.Fatalf("expected *ErrMissing, got %v", err)
+	}
+	if e.Lang != "es" || e.Key != "home.subtitle" || s != "" {
+		t.Fatalf("unexpected error %+v value %q", e, s)
+	}
+	if err.Error() != "i18n: missing translation lang [es] key [home.subtitle]" {
+		t.Fatalf("unexpected error message %q", err)
+	}
+}
@@ -0,0 +1,28 @@
+package i18n
+
+// PrintlnNoMnemonic works like Println removing & accelerator markers used
+// by desktop menus, && escapes are kept as a literal &:
+//
+//	menu.file=&File         -> File
+//	menu.save=Save && E&xit -> Save & Exit
+//
+// So one translation serves menus and plain text. A trailing & is kept.
+func PrintlnNoMnemonic(lang, key string) string {
+	return stripMnemonic(Println(lang, key))
+}
+
+// stripMnemonic returns s without & markers, see PrintlnNoMnemonic.
+func stripMnemonic(s string) string {
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '&' || i == len(s)-1 {
+			b = append(b, s[i])
+			continue
+		}
+		if s[i+1] == '&' {
+			b = append(b, '&')
+			i++
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+)
+
+func TestPrintlnNoMnemonic(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "menu.file=&File\nmenu.save=Save && E&xit\nmenu.rock=Rock &&& Roll\nmenu.end=Tom &\nplain=Plain\n",
+		"es": "menu.file=&Archivo\n",
+	})
+	defer reset()
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "menu.file", "File"},
+		{"es", "menu.file", "Archivo"},
+		{"en", "menu.save", "Save & Exit"},
+		{"en", "menu.rock", "Rock & Roll"},
+		{"en", "menu.end", "Tom &"},
+		{"en", "plain", "Plain"},
+		{"en", "&missing", "missing"},
+	}
+	for _, x := range table {
+		if s := PrintlnNoMnemonic(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+	if s := Println("en", "menu.save"); s != "Save && E&xit" {
+		t.Fatalf("expected Println to keep accelerators, got %q", s)
+	}
+
+	tmpl := template.Must(template.New("").Funcs(FuncMap).Parse(`{{i18nplain "en" "menu.save"}}`))
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s := b.String(); s != "Save &amp; Exit" {
+		t.Fatalf("expected escaped plain text, got %q", s)
+	}
+}
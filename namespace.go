@@ -0,0 +1,40 @@
+package i18n
+
+import "strings"
+
+// nsDefaults contains default languages per namespace.
+var nsDefaults = make(map[string]string)
+
+// SetNamespaceDefault sets the default language used as fallback for keys in
+// namespace instead of the Load default language. Namespace is the first
+// segment of a dotted key: namespace.section.key
+//
+// An empty lang disables default language fallback for the namespace.
+func SetNamespaceDefault(namespace, lang string) {
+	mut.Lock()
+	defer mut.Unlock()
+	invalidate()
+	nsDefaults[namespace] = lang
+}
+
+// namespace returns the first segment of a dotted key.
+func namespace(key string) string {
+	i := strings.Index(key, ".")
+	if i < 0 {
+		return key
+	}
+	return key[:i]
+}
+
+// defaultLang returns the default language for key, false if key has no
+// default language fallback. Caller must hold mut.
+func defaultLang(key string) (string, bool) {
+	if len(nsDefaults) < 1 {
+		return defLang, true
+	}
+	lang, ok := nsDefaults[namespace(key)]
+	if !ok {
+		return defLang, true
+	}
+	return lang, lang != ""
+}
@@ -0,0 +1,32 @@
+package i18n
+
+import "testing"
+
+func TestSetNamespaceDefault(t *testing.T) {
+	setup(t, "es", map[string]string{
+		"en": "emails.welcome=Welcome\nlegal.terms=Terms\nhome.title=Home\n",
+		"es": "emails.welcome=Bienvenido\nlegal.terms=Términos\nhome.title=Inicio\n",
+		"fr": "other=Autre\n",
+	})
+	defer reset()
+	SetNamespaceDefault("emails", "en")
+	SetNamespaceDefault("legal", "")
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"fr", "emails.welcome", "Welcome"},
+		{"fr", "legal.terms", "legal.terms"},
+		{"fr", "home.title", "Inicio"},
+		{"es", "legal.terms", "Términos"},
+		{"fr-CA", "emails.welcome", "Welcome"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// served languages allowlist, nil serves every loaded language.
+	served map[string]bool
+	// preview serves every loaded language ignoring served allowlist.
+	preview bool
+)
+
+// SetServed sets the languages publicly available through Negotiate,
+// Languages and SupportedHeader. Other loaded languages are drafts: they
+// still translate on lookups but aren't negotiated. Calling it without
+// languages serves every loaded language.
+func SetServed(langs ...string) {
+	mut.Lock()
+	defer mut.Unlock()
+	if len(langs) < 1 {
+		served = nil
+		return
+	}
+	served = make(map[string]bool)
+	for _, lang := range langs {
+		served[cleanLang(lang)] = true
+	}
+}
+
+// SetPreview makes Negotiate, Languages and SupportedHeader ignore the
+// SetServed allowlist, so draft languages can be previewed.
+func SetPreview(enabled bool) {
+	mut.Lock()
+	defer mut.Unlock()
+	preview = enabled
+}
+
+// servedLanguages returns sorted loaded languages allowed by SetServed.
+// Caller must hold mut.
+func servedLanguages() []string {
+	list := languages()
+	if served == nil || preview {
+		return list
+	}
+	var s []string
+	for _, lang := range list {
+		if served[lang] {
+			s = append(s, lang)
+		}
+	}
+	return s
+}
+
+// Negotiate returns the best served language for an Accept-Language header
+// value, e.g.: es-MX,es;q=0.9,en;q=0.8
+//
+// Each preferred language matches exactly or by its base language (es-AR
+// matches es). The clean default language is returned if none matches, even
+// if SetServed leaves it out, since it serves every missing translation.
+func Negotiate(acceptLanguage string) string {
+	mut.RLock()
+	defer mut.RUnlock()
+	available := make(map[string]bool)
+	for _, lang := range servedLanguages() {
+		available[lang] = true
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		lang := cleanLang(tag)
+		if available[lang] {
+			return lang
+		}
+		if i := strings.IndexAny(lang, "-_"); i > -1 && available[lang[:i]] {
+			return lang[:i]
+		}
+	}
+	return cleanLang(defLang)
+}
+
+// parseAcceptLanguage returns languages of an Accept-Language header sorted
+// by quality, wildcards and languages with q=0 are skipped.
+func parseAcceptLanguage(s string) []string {
+	type tag struct {
+		lang string
+		q    float64
+	}
+	var tags []tag
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.Split(part, ";")
+		lang := strings.TrimSpace(fields[0])
+		if lang == "" || lang == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, tag{lang, q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+	list := make([]string, len(tags))
+	for i := range tags {
+		list[i] = tags[i].lang
+	}
+	return list
+}
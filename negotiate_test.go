@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en":    "a=a\n",
+		"es":    "a=a\n",
+		"pt-BR": "a=a\n",
+		"fr":    "a=a\n",
+	})
+
+	table := []struct {
+		Header   string
+		Expected string
+	}{
+		{"es-MX,es;q=0.9,en;q=0.8", "es"},
+		{"de,fr;q=0.5,es;q=0.7", "es"},
+		{"pt-BR", "pt-br"},
+		{"de, *;q=0.1", "en"},
+		{"es;q=0,fr", "fr"},
+		{"", "en"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Negotiate(x.Header); s != x.Expected {
+			t.Errorf("%q expected %q, got %q", x.Header, x.Expected, s)
+		}
+	}
+}
+
+func TestSetServed(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home=Home\n",
+		"es": "home=Inicio\n",
+		"fr": "home=Accueil\n",
+	})
+	defer reset()
+	SetServed("en", "ES")
+
+	if s := Negotiate("fr,es;q=0.5"); s != "es" {
+		t.Fatalf("expected draft language skipped, got %q", s)
+	}
+	if s := Negotiate("fr"); s != "en" {
+		t.Fatalf("expected default language, got %q", s)
+	}
+	if s := strings.Join(Languages(), ","); s != "en,es" {
+		t.Fatalf("unexpected languages %q", s)
+	}
+	if s := SupportedHeader(); s != "en,es;q=0.9" {
+		t.Fatalf("unexpected header %q", s)
+	}
+	// drafts still translate.
+	if s := Println("fr", "home"); s != "Accueil" {
+		t.Fatalf("expected draft translation, got %q", s)
+	}
+
+	SetPreview(true)
+	if s := Negotiate("fr,es;q=0.5"); s != "fr" {
+		t.Fatalf("expected draft language in preview, got %q", s)
+	}
+	if s := strings.Join(Languages(), ","); s != "en,es,fr" {
+		t.Fatalf("unexpected languages %q", s)
+	}
+
+	SetPreview(false)
+	SetServed("es")
+	if s := Negotiate("fr"); s != "en" {
+		t.Fatalf("expected default language left out of served, got %q", s)
+	}
+	SetServed()
+	if s := Negotiate("fr"); s != "fr" {
+		t.Fatalf("expected every language served, got %q", s)
+	}
+
+	setup(t, "EN-us", map[string]string{"en-us": "home=Home\n"})
+	if s := Negotiate("de"); s != "en-us" {
+		t.Fatalf("expected clean default language, got %q", s)
+	}
+}
@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// numberSeparators contains built-in grouping and decimal separators by
+// language.
+var numberSeparators = map[string][2]string{
+	"en":    {",", "."},
+	"es":    {".", ","},
+	"es-mx": {",", "."},
+	"pt":    {".", ","},
+	"fr":    {"\u202f", ","},
+	"de":    {".", ","},
+	"it":    {".", ","},
+	"nl":    {".", ","},
+	"ru":    {"\u00a0", ","},
+	"pl":    {"\u00a0", ","},
+	"ja":    {",", "."},
+	"zh":    {",", "."},
+}
+
+// numberFormats contains SetNumberFormat overrides by clean language.
+var numberFormats = make(map[string][2]string)
+
+// SetNumberFormat overrides FormatNumber grouping and decimal separators for
+// lang, e.g. a house style:
+//
+//	i18n.SetNumberFormat("de-CH", "'", ".")
+//
+// Overrides of a base language apply to its regions without overrides.
+func SetNumberFormat(lang, grouping, decimal string) {
+	mut.Lock()
+	defer mut.Unlock()
+	numberFormats[cleanLang(lang)] = [2]string{grouping, decimal}
+	invalidate()
+}
+
+// FormatNumber returns v rounded to decimals digits with lang grouping and
+// decimal separators, e.g. 1.234.567,89 for es. Languages without data use
+// english separators.
+func FormatNumber(lang string, v float64, decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	mut.RLock()
+	defer mut.RUnlock()
+	return formatNumber(lang, v, decimals)
+}
+
+// formatNumber works like FormatNumber, decimals -1 uses the fewest digits
+// representing v. Caller must hold mut.
+func formatNumber(lang string, v float64, decimals int) string {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return strconv.FormatFloat(v, 'f', decimals, 64)
+	}
+	l := localeFor(lang)
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	integer, fraction := s, ""
+	if i := strings.Index(s, "."); i > -1 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+
+	return groupDigits(l, v < 0 && strings.Trim(s, "0.") != "", integer, fraction)
+}
+
+// formatInteger returns integer s, as strconv.FormatInt returns it, with
+// lang grouping separators. Caller must hold mut.
+func formatInteger(lang, s string) string {
+	neg := strings.HasPrefix(s, "-")
+	return groupDigits(localeFor(lang), neg, strings.TrimPrefix(s, "-"), "")
+}
+
+// groupDigits joins integer and fraction digits with l separators.
+func groupDigits(l *locale, neg bool, integer, fraction string) string {
+	var b bytes.Buffer
+	if neg {
+		b.WriteString("-")
+	}
+	for i := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(l.grouping)
+		}
+		b.WriteByte(integer[i])
+	}
+	if fraction != "" {
+		b.WriteString(l.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// numberFormat returns grouping and decimal separators of clean lang,
+// overrides go first. Caller must hold mut.
+func numberFormat(lang string) (string, string) {
+	baseLang := lang
+	if i := strings.IndexAny(lang, "-_"); i > -1 {
+		baseLang = lang[:i]
+	}
+	for _, m := range []map[string][2]string{numberFormats, numberSeparators} {
+		if f, ok := m[lang]; ok {
+			return f[0], f[1]
+		}
+		if f, ok := m[baseLang]; ok {
+			return f[0], f[1]
+		}
+	}
+	f := numberSeparators["en"]
+	return f[0], f[1]
+}
@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatNumber(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "a=a\n",
+	})
+	defer reset()
+
+	table := []struct {
+		Lang     string
+		Value    float64
+		Decimals int
+		Expected string
+	}{
+		{"en", 1234567.891, 2, "1,234,567.89"},
+		{"es", 1234567.891, 2, "1.234.567,89"},
+		{"es-MX", 1234567.891, 2, "1,234,567.89"},
+		{"es-AR", 1234.5, 1, "1.234,5"},
+		{"fr", 1234.6, 0, "1\u202f235"},
+		{"xx", -999.5, 1, "-999.5"},
+		{"en", -0.001, 2, "0.00"},
+		{"en", 100, 0, "100"},
+		{"es", math.Inf(1), 2, "+Inf"},
+		{"es", math.Inf(-1), 0, "-Inf"},
+		{"fr", math.NaN(), 2, "NaN"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := FormatNumber(x.Lang, x.Value, x.Decimals); s != x.Expected {
+			t.Errorf("%s:%v expected %q, got %q", x.Lang, x.Value, x.Expected, s)
+		}
+	}
+
+	// warmed data is rebuilt with overrides.
+	Warm("de-CH", "es")
+	SetNumberFormat("de-CH", "'", ".")
+	SetNumberFormat("es", " ", ",")
+	table = []struct {
+		Lang     string
+		Value    float64
+		Decimals int
+		Expected string
+	}{
+		{"de-CH", 1234567.5, 2, "1'234'567.50"},
+		{"de", 1234567.5, 2, "1.234.567,50"},
+		{"es", 1234.5, 1, "1 234,5"},
+		{"es-MX", 1234.5, 1, "1 234,5"},
+		{"en", 1234.5, 1, "1,234.5"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := FormatNumber(x.Lang, x.Value, x.Decimals); s != x.Expected {
+			t.Errorf("%s:%v expected %q, got %q", x.Lang, x.Value, x.Expected, s)
+		}
+	}
+}
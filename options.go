@@ -0,0 +1,270 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Option configures Load.
+type Option func(*options)
+
+type options struct {
+	maxLen     int
+	maxLenKeys map[string]int
+	lengths    LengthError
+	warnings   []string
+	charset    string
+	sources    map[string]string
+	deprecated map[string]string
+	// attributions by lang:key.
+	attributions map[string]string
+	trimKey      string
+	strict       bool
+	rejectEmpty  bool
+	splitLast    bool
+	markers      []string
+	// marked are lang:key values containing markers.
+	marked     []string
+	escapes    bool
+	namespace  string
+	separators *strings.Replacer
+	// defLang is read from the .default marker file.
+	defLang string
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		sources:      make(map[string]string),
+		deprecated:   make(map[string]string),
+		attributions: make(map[string]string),
+	}
+	for i := range opts {
+		opts[i](o)
+	}
+	return o
+}
+
+// MaxLength flags values longer than max characters (runes). perKey
+// overrides max for specific keys, zero means no limit.
+//
+// Load returns a LengthError listing every violation, the catalog is loaded
+// anyway.
+func MaxLength(max int, perKey map[string]int) Option {
+	return func(o *options) {
+		o.maxLen = max
+		o.maxLenKeys = perKey
+	}
+}
+
+// TrimKeyChars removes leading and trailing characters contained in cutset
+// from loaded keys, e.g. with cutset `"*` the line "home.title"=Home loads
+// key home.title
+func TrimKeyChars(cutset string) Option {
+	return func(o *options) {
+		o.trimKey = cutset
+	}
+}
+
+// Strict makes Load fail on any LoadVerbose warning, the catalog is left
+// untouched.
+func Strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// SplitOnLast splits lines on the last separator instead of the first, for
+// formats where values never contain the separator but keys may, e.g. with
+// separator = the line a=b=c loads key a=b with value c.
+func SplitOnLast() Option {
+	return func(o *options) {
+		o.splitLast = true
+	}
+}
+
+// RejectEmptyFiles makes Load fail when a file yields no valid key/value
+// pairs, usually a truncated or wrongly formatted file, instead of warning
+// about it. Hidden files like .DS_Store count too, keep them out of dir.
+func RejectEmptyFiles() Option {
+	return func(o *options) {
+		o.rejectEmpty = true
+	}
+}
+
+// DefaultMarkers are the markers RejectMarkers uses if none is given.
+var DefaultMarkers = []string{"TODO", "FIXME", "XXX"}
+
+// RejectMarkers makes Load fail if any value contains one of markers, e.g.
+// unfinished translations like "TODO translate", the catalog is left
+// untouched. Markers are case sensitive and match whole words only, so
+// spanish todos doesn't match TODO. Without markers DefaultMarkers are
+// used.
+func RejectMarkers(markers ...string) Option {
+	if len(markers) < 1 {
+		markers = DefaultMarkers
+	}
+	return func(o *options) {
+		o.markers = markers
+	}
+}
+
+// Namespace prefixes loaded keys with ns and a dot, e.g. key button.ok
+// loads as lib.button.ok, so catalogs of different directories don't
+// collide. See SetNamespaceDefault.
+func Namespace(ns string) Option {
+	return func(o *options) {
+		o.namespace = ns
+	}
+}
+
+// KeySeparators replaces every character of others in keys with canonical
+// path separator, e.g. with KeySeparators(".", "/_") keys menu/home,
+// menu_home and menu.home load as menu.home. Lookups are normalized the same
+// way until the next Load using KeySeparators.
+func KeySeparators(canonical, others string) Option {
+	pairs := make([]string, 0, 2*len(others))
+	for _, r := range others {
+		pairs = append(pairs, string(r), canonical)
+	}
+	return func(o *options) {
+		o.separators = strings.NewReplacer(pairs...)
+	}
+}
+
+// Escapes converts escape sequences \n (new line), \t (tab) and \\
+// (backslash) in loaded values, other backslashes are kept. Off by default,
+// values are loaded verbatim.
+func Escapes() Option {
+	return func(o *options) {
+		o.escapes = true
+	}
+}
+
+// unescape returns s with escape sequences converted, see Escapes.
+func unescape(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b = append(b, s[i])
+			continue
+		}
+		switch s[i+1] {
+		case 'n':
+			b = append(b, '\n')
+		case 't':
+			b = append(b, '\t')
+		case '\\':
+			b = append(b, '\\')
+		default:
+			b = append(b, s[i])
+			continue
+		}
+		i++
+	}
+	return string(b)
+}
+
+// check validates a loaded value against options.
+func (o *options) check(lang, key, value string) {
+	for _, marker := range o.markers {
+		if containsWord(value, marker) {
+			o.marked = append(o.marked, bullet(lang, key))
+			break
+		}
+	}
+	max := o.maxLen
+	if n, ok := o.maxLenKeys[key]; ok {
+		max = n
+	}
+	if max > 0 {
+		if n := utf8.RuneCountInString(value); n > max {
+			o.lengths = append(o.lengths, LengthViolation{
+				Key:    bullet(lang, key),
+				Length: n,
+				Max:    max,
+			})
+		}
+	}
+}
+
+// warn adds a LoadVerbose warning.
+func (o *options) warn(format string, args ...interface{}) {
+	o.warnings = append(o.warnings, fmt.Sprintf(format, args...))
+}
+
+// rejected returns the error of options failing the load, see Strict and
+// RejectMarkers.
+func (o *options) rejected() error {
+	if o.strict && len(o.warnings) > 0 {
+		return fmt.Errorf("i18n: strict load: %s", strings.Join(o.warnings, "; "))
+	}
+	if len(o.marked) > 0 {
+		sort.Strings(o.marked)
+		return fmt.Errorf("i18n: values with markers: %s", strings.Join(o.marked, ", "))
+	}
+	return nil
+}
+
+// containsWord reports if s contains word not surrounded by letters or
+// digits.
+func containsWord(s, word string) bool {
+	if word == "" {
+		return false
+	}
+	for i := 0; i <= len(s)-len(word); {
+		j := strings.Index(s[i:], word)
+		if j < 0 {
+			return false
+		}
+		j += i
+		before, _ := utf8.DecodeLastRuneInString(s[:j])
+		after, _ := utf8.DecodeRuneInString(s[j+len(word):])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		i = j + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// err returns validation errors found while loading.
+func (o *options) err() error {
+	if len(o.lengths) > 0 {
+		sort.Sort(o.lengths)
+		return o.lengths
+	}
+	return nil
+}
+
+// LengthViolation is a value longer than its max length.
+type LengthViolation struct {
+	// Key is lang:key.
+	Key    string
+	Length int
+	Max    int
+}
+
+// LengthError is returned by Load when values exceed MaxLength limits.
+type LengthError []LengthViolation
+
+func (e LengthError) Error() string {
+	s := make([]string, len(e))
+	for i := range e {
+		s[i] = fmt.Sprintf("%s (%d > %d)", e[i].Key, e[i].Length, e[i].Max)
+	}
+	return "i18n: values exceed max length: " + strings.Join(s, ", ")
+}
+
+func (e LengthError) Len() int           { return len(e) }
+func (e LengthError) Less(i, j int) bool { return e[i].Key < e[j].Key }
+func (e LengthError) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
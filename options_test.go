@@ -0,0 +1,265 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMaxLength(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en": "btn.ok=OK\nbtn.cancel=Cancel\ntooltip=A long tooltip text\n",
+		"de": "btn.ok=OK\nbtn.cancel=Abbrechen\ntooltip=Ein kurzer Text\n",
+	})
+	defer os.RemoveAll(dir)
+
+	err := Load(dir, "en", "", "", MaxLength(15, map[string]int{"btn.cancel": 8}))
+	report, ok := err.(LengthError)
+	if !ok {
+		t.Fatalf("expected LengthError, got %v", err)
+	}
+	expected := LengthError{
+		{Key: "de:btn.cancel", Length: 9, Max: 8},
+		{Key: "en:tooltip", Length: 19, Max: 15},
+	}
+	if len(report) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, report)
+	}
+	for i := range expected {
+		if report[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected[i], report[i])
+		}
+	}
+
+	// catalog is loaded anyway.
+	if s := Println("de", "btn.cancel"); s != "Abbrechen" {
+		t.Fatalf("expected value loaded, got %q", s)
+	}
+
+	if err := Load(dir, "en", "", "", MaxLength(20, nil)); err != nil {
+		t.Fatalf("expected no violations, got %s", err)
+	}
+}
+
+func TestTrimKeyChars(t *testing.T) {
+	reset()
+	dir := writeFiles(t, map[string]string{
+		"en": "\"home.title\"=Home \"quoted\"\n*menu.file*=File\nplain=Plain\n",
+	})
+	defer os.RemoveAll(dir)
+
+	if err := Load(dir, "en", "", "", TrimKeyChars(`"*`)); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	table := map[string]string{
+		"home.title": `Home "quoted"`,
+		"menu.file":  "File",
+		"plain":      "Plain",
+	}
+	for key, expected := range table {
+		if s := Println("en", key); s != expected {
+			t.Errorf("%s expected %q, got %q", key, expected, s)
+		}
+	}
+
+	reset()
+	if err := Load(dir, "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if s := Println("en", `"home.title"`); s != `Home "quoted"` {
+		t.Fatalf("expected no trimming by default, got %q", s)
+	}
+}
+
+func TestEscapes(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en": `lines=One\nTwo` + "\n" + `tab=A\tB` + "\n" + `path=C:\\dir\\file` + "\n" +
+			`other=50\% off\` + "\n",
+	})
+	defer os.RemoveAll(dir)
+
+	reset()
+	if err := Load(dir, "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if s := Println("en", "lines"); s != `One\nTwo` {
+		t.Fatalf("expected literal backslash when off, got %q", s)
+	}
+
+	reset()
+	if err := Load(dir, "en", "", "", Escapes()); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "lines", "One\nTwo"},
+		{"en", "tab", "A\tB"},
+		{"en", "path", `C:\dir\file`},
+		{"en", "other", `50\% off\`},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
+
+func TestKeySeparators(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en": "menu/home=Home\nmenu_file=File\nmenu.edit=Edit\n",
+		"es": "menu.home=Inicio\n",
+	})
+	defer os.RemoveAll(dir)
+
+	reset()
+	if err := Load(dir, "en", "", "", KeySeparators(".", "/_")); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "menu.home", "Home"},
+		{"en", "menu_home", "Home"},
+		{"es", "menu/home", "Inicio"},
+		{"en", "menu/file", "File"},
+		{"en", "menu_edit", "Edit"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+	if list := Keys("en"); len(list) != 3 || list[0] != "menu.edit" || list[1] != "menu.file" {
+		t.Fatalf("expected canonical keys, got %v", list)
+	}
+}
+
+func TestRejectEmptyFiles(t *testing.T) {
+	reset()
+	defer reset()
+	dir := writeFiles(t, map[string]string{
+		"en": "hello=Hello\n",
+		"fr": "",
+		"de": "  \n\t\n",
+	})
+	defer os.RemoveAll(dir)
+
+	warnings, err := LoadVerbose(dir, "en", "", "")
+	if err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	expected := []string{
+		filepath.Join(dir, "de") + `: malformed line "  "`,
+		filepath.Join(dir, "de") + `: malformed line "\t"`,
+		filepath.Join(dir, "de") + ": skipped file, no valid lines",
+		filepath.Join(dir, "fr") + ": skipped file, empty",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expected warnings:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(warnings, "\n"))
+	}
+
+	reset()
+	if err := Load(dir, "en", "", "", RejectEmptyFiles()); err == nil {
+		t.Fatalf("expected error for empty files")
+	}
+	if s := Println("en", "hello"); s != "hello" {
+		t.Fatalf("expected catalog untouched, got %q", s)
+	}
+}
+
+func TestRejectMarkers(t *testing.T) {
+	reset()
+	defer reset()
+	dir := writeFiles(t, map[string]string{
+		"en": "hello=Hello\nbye=TODO: translate\n",
+		"es": "hello=Hola a todos\nbye=Adiós FIXME\nwait=Un momento\n",
+		"fr": "hello=Bonjour TODOS\nbye=Au revoir\n",
+	})
+	defer os.RemoveAll(dir)
+
+	err := Load(dir, "en", "", "", RejectMarkers())
+	if err == nil {
+		t.Fatalf("expected error for markers")
+	}
+	expected := "i18n: values with markers: en:bye, es:bye"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err)
+	}
+	if s := Println("en", "hello"); s != "hello" {
+		t.Fatalf("expected catalog untouched, got %q", s)
+	}
+
+	err = Load(dir, "en", "", "", RejectMarkers("FIXME", "momento"))
+	expected = "i18n: values with markers: es:bye, es:wait"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+
+	// off by default.
+	if err := Load(dir, "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if s := Println("en", "bye"); s != "TODO: translate" {
+		t.Fatalf("expected value loaded, got %q", s)
+	}
+}
+
+func TestSplitOnLast(t *testing.T) {
+	reset()
+	defer reset()
+	dir := writeFiles(t, map[string]string{
+		"en": "1+1=2=two\nurl=https://a.b/?q=1=one\n",
+		"es": "saludo::hola::mundo\n",
+	})
+	defer os.RemoveAll(dir)
+
+	if err := Load(dir, "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if s := Println("en", "1+1"); s != "2=two" {
+		t.Fatalf("expected first separator split, got %q", s)
+	}
+
+	reset()
+	if err := Load(dir, "en", "", "", SplitOnLast()); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "1+1=2", "two"},
+		{"en", "url=https://a.b/?q=1", "one"},
+		{"en", "1+1", "1+1"},
+	}
+	for _, x := range table {
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+
+	// multi char separators.
+	reset()
+	if _, err := LoadVerbose(dir, "es", "::", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if s := Println("es", "saludo"); s != "hola::mundo" {
+		t.Fatalf("expected hola::mundo, got %q", s)
+	}
+	reset()
+	if _, err := LoadVerbose(dir, "es", "::", "", SplitOnLast()); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if s := Println("es", "saludo::hola"); s != "mundo" {
+		t.Fatalf("expected mundo, got %q", s)
+	}
+}
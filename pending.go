@@ -0,0 +1,79 @@
+package i18n
+
+import "sync"
+
+// PendingKey is a translation missing for Lang, see PendingTranslations.
+type PendingKey struct {
+	Lang string
+	Key  string
+	// Default is the default language value served instead, empty if the
+	// key is missing there too.
+	Default string
+}
+
+var (
+	// pendingEnabled enables recording pending translations.
+	pendingEnabled bool
+	pendingMut     sync.Mutex
+	pending        []PendingKey
+	pendingSeen    = make(map[string]bool)
+)
+
+// SetPendingTranslations enables recording Println, Printf and friends
+// lookups of keys lang doesn't translate, served by the default language or
+// missing everywhere, so production misses become a translators worklist,
+// see PendingTranslations. Regions using their base language aren't recorded.
+// Disabled by default, disabling clears recorded keys.
+func SetPendingTranslations(enabled bool) {
+	mut.Lock()
+	defer mut.Unlock()
+	pendingEnabled = enabled
+	if !enabled {
+		pendingMut.Lock()
+		pending = nil
+		pendingSeen = make(map[string]bool)
+		pendingMut.Unlock()
+	}
+}
+
+// PendingTranslations returns keys recorded by SetPendingTranslations once
+// per lang and key, in order of the first lookup.
+func PendingTranslations() []PendingKey {
+	pendingMut.Lock()
+	defer pendingMut.Unlock()
+	return append([]PendingKey(nil), pending...)
+}
+
+// recordPending records lang+key if lang and its base language don't
+// translate it. Caller must hold mut.
+func recordPending(lang, key string) {
+	if !pendingEnabled {
+		return
+	}
+	lang = cleanLang(lang)
+	key = normalizeKey(lang, key)
+	for level := 0; level < 2; level++ {
+		if l, ok := fallback(lang, key, level); ok {
+			if _, ok := value(l + ":" + key); ok {
+				return
+			}
+		}
+	}
+	var def string
+	if l, ok := defaultLang(key); ok {
+		def, _ = value(bullet(l, key))
+	}
+	addPending(lang, key, def)
+}
+
+// addPending records lang+key with default value def once.
+func addPending(lang, key, def string) {
+	pendingMut.Lock()
+	defer pendingMut.Unlock()
+	slug := lang + ":" + key
+	if pendingSeen[slug] {
+		return
+	}
+	pendingSeen[slug] = true
+	pending = append(pending, PendingKey{Lang: lang, Key: key, Default: def})
+}
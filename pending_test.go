@@ -0,0 +1,47 @@
+package i18n
+
+import "testing"
+
+func TestPendingTranslations(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "hello=Hello\nbye=Bye\ngreet=Hello %s\n",
+		"es": "hello=Hola\n",
+	})
+	defer reset()
+
+	Println("es", "bye")
+	if list := PendingTranslations(); len(list) != 0 {
+		t.Fatalf("expected disabled by default, got %v", list)
+	}
+
+	SetPendingTranslations(true)
+	Println("es", "hello")
+	Println("es-MX", "hello")
+	Println("es", "bye")
+	Println("es", "bye")
+	Printf("es", "greet", "Ana")
+	Println("es", "unknown")
+	Println("en", "unknown")
+	Println("es", "unknown")
+
+	expected := []PendingKey{
+		{"es", "bye", "Bye"},
+		{"es", "greet", "Hello %s"},
+		{"es", "unknown", ""},
+		{"en", "unknown", ""},
+	}
+	list := PendingTranslations()
+	if len(list) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, list)
+	}
+	for i := range expected {
+		if list[i] != expected[i] {
+			t.Errorf("%d expected %v, got %v", i, expected[i], list[i])
+		}
+	}
+
+	SetPendingTranslations(false)
+	if list := PendingTranslations(); len(list) != 0 {
+		t.Fatalf("expected cleared, got %v", list)
+	}
+}
@@ -0,0 +1,28 @@
+package i18n
+
+// pipeline contains SetPipeline funcs.
+var pipeline []func(string) string
+
+// SetPipeline sets funcs applied in order to every resolved value of
+// Println, Printf, Plural and friends, after fallback resolution and before
+// formatting args, e.g. to trim and then upper case:
+//
+//	i18n.SetPipeline(strings.TrimSpace, strings.ToUpper)
+//
+// Funcs run on every lookup, results aren't cached, so keep them cheap
+// since each stage usually allocates a new string. Funcs are called holding
+// the catalog read lock and must not call i18n funcs that modify it.
+// SetPipeline() with no funcs disables it.
+func SetPipeline(fns ...func(string) string) {
+	mut.Lock()
+	defer mut.Unlock()
+	pipeline = append([]func(string) string(nil), fns...)
+}
+
+// process returns v transformed by the pipeline. Caller must hold mut.
+func process(v string) string {
+	for _, fn := range pipeline {
+		v = fn(v)
+	}
+	return v
+}
@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetPipeline(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "title=  inbox  \ngreet=  hello %s\ninbox.one=%d message \ninbox.other=%d messages \n",
+		"es": "title= bandeja \n",
+	})
+	defer reset()
+
+	brackets := func(s string) string { return "[" + s + "]" }
+	SetPipeline(strings.TrimSpace, brackets)
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "title", "[inbox]"},
+		{"es", "title", "[bandeja]"},
+		{"es-MX", "title", "[bandeja]"},
+		{"en", "unknown", "unknown"},
+	}
+	for _, x := range table {
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+	if s := Printf("en", "greet", "Ana"); s != "[hello Ana]" {
+		t.Fatalf("expected pipeline before args, got %q", s)
+	}
+	if s := Plural("en", "inbox", 2); s != "[2 messages]" {
+		t.Fatalf("expected pipeline on plurals, got %q", s)
+	}
+	if s, _, _ := Template("en", "title"); s != "  inbox  " {
+		t.Fatalf("expected raw template, got %q", s)
+	}
+
+	// order matters.
+	SetPipeline(brackets, strings.TrimSpace)
+	if s := Println("en", "title"); s != "[  inbox  ]" {
+		t.Fatalf("expected stages in order, got %q", s)
+	}
+	SetPipeline()
+	if s := Println("en", "title"); s != "  inbox  " {
+		t.Fatalf("expected pipeline disabled, got %q", s)
+	}
+}
+
+func TestSetPipelineLookups(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "title= Inbox \nold.title= Old \ncta= Buy \ncta.b= Get it \n",
+		"es": "title= Bandeja \n",
+	})
+	defer reset()
+	SetPipeline(strings.TrimSpace, strings.ToUpper)
+
+	table := []struct {
+		Name     string
+		Value    string
+		Expected string
+	}{
+		{"PrintlnFallbackKey", PrintlnFallbackKey("es", "new.title", "old.title"), "OLD"},
+		{"PrintlnVariantKey", PrintlnVariantKey("es", "cta", "b"), "GET IT"},
+		{"PrintlnDepth", PrintlnDepth("es-MX", "title", 1), "BANDEJA"},
+		{"Get", Get("es", "title").Value, "BANDEJA"},
+		{"Flatten", Flatten("es")["cta"], "BUY"},
+		{"Flatten", Flatten("es")["title"], "BANDEJA"},
+	}
+	for _, x := range table {
+		if x.Value != x.Expected {
+			t.Errorf("%s expected %q, got %q", x.Name, x.Expected, x.Value)
+		}
+	}
+	if v, served, ok := Resolve("es-MX", "cta"); v != "BUY" || served != "en" || !ok {
+		t.Fatalf("expected Resolve BUY en, got %q %q %v", v, served, ok)
+	}
+}
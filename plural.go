@@ -0,0 +1,260 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Plural categories as defined by CLDR.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// pluralRule selects the plural category for an integer.
+type pluralRule struct {
+	// categories used by the rule.
+	categories []string
+	category   func(n int) string
+}
+
+var (
+	ruleOne = pluralRule{
+		categories: []string{PluralOne, PluralOther},
+		category: func(n int) string {
+			if n == 1 {
+				return PluralOne
+			}
+			return PluralOther
+		},
+	}
+	ruleZeroOne = pluralRule{
+		categories: []string{PluralOne, PluralOther},
+		category: func(n int) string {
+			if n == 0 || n == 1 {
+				return PluralOne
+			}
+			return PluralOther
+		},
+	}
+	ruleOther = pluralRule{
+		categories: []string{PluralOther},
+		category: func(n int) string {
+			return PluralOther
+		},
+	}
+	ruleEastSlavic = pluralRule{
+		categories: []string{PluralOne, PluralFew, PluralMany, PluralOther},
+		category: func(n int) string {
+			switch {
+			case n%10 == 1 && n%100 != 11:
+				return PluralOne
+			case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+				return PluralFew
+			}
+			return PluralMany
+		},
+	}
+	rulePolish = pluralRule{
+		categories: []string{PluralOne, PluralFew, PluralMany, PluralOther},
+		category: func(n int) string {
+			switch {
+			case n == 1:
+				return PluralOne
+			case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+				return PluralFew
+			}
+			return PluralMany
+		},
+	}
+	ruleSouthSlavic = pluralRule{
+		categories: []string{PluralOne, PluralFew, PluralOther},
+		category: func(n int) string {
+			switch {
+			case n%10 == 1 && n%100 != 11:
+				return PluralOne
+			case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+				return PluralFew
+			}
+			return PluralOther
+		},
+	}
+	ruleCzech = pluralRule{
+		categories: []string{PluralOne, PluralFew, PluralOther},
+		category: func(n int) string {
+			switch {
+			case n == 1:
+				return PluralOne
+			case n >= 2 && n <= 4:
+				return PluralFew
+			}
+			return PluralOther
+		},
+	}
+	ruleArabic = pluralRule{
+		categories: []string{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther},
+		category: func(n int) string {
+			switch {
+			case n == 0:
+				return PluralZero
+			case n == 1:
+				return PluralOne
+			case n == 2:
+				return PluralTwo
+			case n%100 >= 3 && n%100 <= 10:
+				return PluralFew
+			case n%100 >= 11:
+				return PluralMany
+			}
+			return PluralOther
+		},
+	}
+	ruleHebrew = pluralRule{
+		categories: []string{PluralOne, PluralTwo, PluralOther},
+		category: func(n int) string {
+			switch n {
+			case 1:
+				return PluralOne
+			case 2:
+				return PluralTwo
+			}
+			return PluralOther
+		},
+	}
+	ruleRomanian = pluralRule{
+		categories: []string{PluralOne, PluralFew, PluralOther},
+		category: func(n int) string {
+			switch {
+			case n == 1:
+				return PluralOne
+			case n == 0 || n%100 >= 1 && n%100 <= 19:
+				return PluralFew
+			}
+			return PluralOther
+		},
+	}
+)
+
+// pluralRules contains CLDR cardinal rules for integers by language.
+// Languages not listed use one for 1 and other for everything else.
+var pluralRules = map[string]pluralRule{
+	"fr": ruleZeroOne, "pt": ruleZeroOne,
+	"ja": ruleOther, "zh": ruleOther, "ko": ruleOther, "th": ruleOther,
+	"vi": ruleOther, "id": ruleOther, "ms": ruleOther,
+	"ru": ruleEastSlavic, "uk": ruleEastSlavic, "be": ruleEastSlavic,
+	"pl": rulePolish,
+	"hr": ruleSouthSlavic, "sr": ruleSouthSlavic, "bs": ruleSouthSlavic,
+	"cs": ruleCzech, "sk": ruleCzech,
+	"ar": ruleArabic,
+	"he": ruleHebrew, "iw": ruleHebrew,
+	"ro": ruleRomanian, "mo": ruleRomanian,
+	"pt-pt": ruleOne,
+}
+
+// pluralRuleFor returns the plural rule for lang. Caller must hold mut.
+func pluralRuleFor(lang string) pluralRule {
+	lang = cleanLang(lang)
+	baseLang := lang
+	if i := strings.IndexAny(lang, "-_"); i > -1 {
+		baseLang = lang[:i]
+	}
+	for _, rules := range []map[string]pluralRule{customPluralRules, pluralRules} {
+		if r, ok := rules[lang]; ok {
+			return r
+		}
+		if r, ok := rules[baseLang]; ok {
+			return r
+		}
+	}
+	return ruleOne
+}
+
+// PluralCategory returns the CLDR plural category of n in lang: zero, one,
+// two, few, many or other. It's the category Plural uses, including rules
+// set with LoadPluralRules.
+func PluralCategory(lang string, n int) string {
+	mut.RLock()
+	defer mut.RUnlock()
+	return pluralCategory(lang, n)
+}
+
+// pluralCategory returns the plural category of n in lang. Caller must hold
+// mut.
+func pluralCategory(lang string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	return localeFor(lang).plural.category(n)
+}
+
+// Plural returns translation of key.<category> for count, where category
+// is the CLDR plural category of count in lang (zero, one, two, few, many,
+// other), falling back to key.other:
+//
+//	inbox.one=%d message
+//	inbox.other=%d messages
+//
+// If args is empty count is used as the only arg for values with verbs.
+func Plural(lang, key string, count int, args ...interface{}) string {
+	return PluralSelect(lang, key, count, "", args...)
+}
+
+// PluralCount works like Plural using the length of items as count, items
+// can be a slice, array, map, channel or pointer to one of them. Other
+// values count as 1. In templates:
+//
+//	{{i18ncount .Lang "items" .Items}}
+func PluralCount(lang, key string, items interface{}, args ...interface{}) string {
+	return Plural(lang, key, length(items), args...)
+}
+
+// length returns the length of collection v, 1 for other values.
+func length(v interface{}) int {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return rv.Len()
+	}
+	return 1
+}
+
+// PluralSelect works like Plural combining count with a gender (or any
+// other select value), tried in order:
+//
+//	key.<gender>.<category>
+//	key.<category>
+//	key.<gender>.other
+//	key.other
+//
+// Empty gender skips gender keys.
+func PluralSelect(lang, key string, count int, gender string, args ...interface{}) string {
+	cat := PluralCategory(lang, count)
+	var keys []string
+	if gender != "" {
+		keys = append(keys, key+"."+gender+"."+cat)
+	}
+	keys = append(keys, key+"."+cat)
+	if gender != "" {
+		keys = append(keys, key+"."+gender+"."+PluralOther)
+	}
+	keys = append(keys, key+"."+PluralOther)
+
+	v, _, ok := translateKeys(lang, fallbackLevels, key, keys...)
+	if !ok {
+		return v
+	}
+	if len(args) < 1 {
+		if !strings.Contains(v, "%") {
+			return v
+		}
+		args = []interface{}{count}
+	}
+	return sprintf(v, args...)
+}
@@ -0,0 +1,198 @@
+package i18n
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// customPluralRules contains plural rules loaded by LoadPluralRules, they
+// take precedence over built-in rules.
+var customPluralRules = make(map[string]pluralRule)
+
+// LoadPluralRules reads plural rules used by Plural and friends, replacing
+// built-in rules of each language found. Lines have format:
+//
+//	<lang> <category>: <condition>
+//
+// Conditions are CLDR like expressions over integer operand n (or i):
+// relations joined with and/or (and binds tighter), where a relation is
+// n [% divisor] = list or n [% divisor] != list and list contains
+// comma separated values or ranges (a..b). mod is accepted as %.
+// Categories are tested in order, other is used when none matches.
+// Empty lines and lines starting with # are skipped. Example:
+//
+//	# russian
+//	ru one: n % 10 = 1 and n % 100 != 11
+//	ru few: n % 10 = 2..4 and n % 100 != 12..14
+//	ru many: n % 10 = 0 or n % 10 = 5..9 or n % 100 = 11..14
+func LoadPluralRules(r io.Reader) error {
+	type clause struct {
+		category  string
+		condition func(n int) bool
+	}
+	clauses := make(map[string][]clause)
+	var order []string
+
+	scan := bufio.NewScanner(r)
+	var line int
+	for scan.Scan() {
+		line++
+		s := strings.TrimSpace(scan.Text())
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+		i := strings.Index(s, ":")
+		head := strings.Fields(s[:i+1])
+		if i < 0 || len(head) != 2 {
+			return fmt.Errorf("i18n: plural rules line %d: expected <lang> <category>: <condition>", line)
+		}
+		lang, category := cleanLang(head[0]), strings.TrimSuffix(head[1], ":")
+		if !isPluralCategory(category) {
+			return fmt.Errorf("i18n: plural rules line %d: unknown category %q", line, category)
+		}
+		cond, err := parseCondition(s[i+1:])
+		if err != nil {
+			return fmt.Errorf("i18n: plural rules line %d: %s", line, err)
+		}
+		if _, ok := clauses[lang]; !ok {
+			order = append(order, lang)
+		}
+		clauses[lang] = append(clauses[lang], clause{category, cond})
+	}
+	if err := scan.Err(); err != nil {
+		return err
+	}
+
+	mut.Lock()
+	defer mut.Unlock()
+	invalidate()
+	for _, lang := range order {
+		list := clauses[lang]
+		rule := pluralRule{
+			category: func(n int) string {
+				for i := range list {
+					if list[i].condition(n) {
+						return list[i].category
+					}
+				}
+				return PluralOther
+			},
+		}
+		for i := range list {
+			if !seen(rule.categories, list[i].category) {
+				rule.categories = append(rule.categories, list[i].category)
+			}
+		}
+		if !seen(rule.categories, PluralOther) {
+			rule.categories = append(rule.categories, PluralOther)
+		}
+		customPluralRules[lang] = rule
+	}
+	return nil
+}
+
+func isPluralCategory(s string) bool {
+	switch s {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return true
+	}
+	return false
+}
+
+// parseCondition parses an or/and expression of relations.
+func parseCondition(s string) (func(n int) bool, error) {
+	var ors []func(n int) bool
+	for _, or := range strings.Split(s, " or ") {
+		var ands []func(n int) bool
+		for _, and := range strings.Split(or, " and ") {
+			rel, err := parseRelation(and)
+			if err != nil {
+				return nil, err
+			}
+			ands = append(ands, rel)
+		}
+		ors = append(ors, func(n int) bool {
+			for i := range ands {
+				if !ands[i](n) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	return func(n int) bool {
+		for i := range ors {
+			if ors[i](n) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// parseRelation parses: n [% divisor] (=|!=) list
+func parseRelation(s string) (func(n int) bool, error) {
+	s = strings.Replace(s, " mod ", " % ", -1)
+	negate := false
+	i := strings.Index(s, "!=")
+	if i > -1 {
+		negate = true
+	} else {
+		i = strings.Index(s, "=")
+	}
+	if i < 0 {
+		return nil, fmt.Errorf("missing = or != in %q", strings.TrimSpace(s))
+	}
+	left, right := strings.TrimSpace(s[:i]), s[i+1:]
+	if negate {
+		right = s[i+2:]
+	}
+
+	divisor := 0
+	if j := strings.Index(left, "%"); j > -1 {
+		d, err := strconv.Atoi(strings.TrimSpace(left[j+1:]))
+		if err != nil || d < 1 {
+			return nil, fmt.Errorf("invalid divisor in %q", left)
+		}
+		divisor = d
+		left = strings.TrimSpace(left[:j])
+	}
+	if left != "n" && left != "i" {
+		return nil, fmt.Errorf("unknown operand %q", left)
+	}
+
+	var ranges [][2]int
+	for _, item := range strings.Split(right, ",") {
+		item = strings.TrimSpace(item)
+		from, to := item, item
+		if j := strings.Index(item, ".."); j > -1 {
+			from, to = item[:j], item[j+2:]
+		}
+		a, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", item)
+		}
+		b, err := strconv.Atoi(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", item)
+		}
+		ranges = append(ranges, [2]int{a, b})
+	}
+
+	return func(n int) bool {
+		if divisor > 0 {
+			n %= divisor
+		}
+		in := false
+		for i := range ranges {
+			if n >= ranges[i][0] && n <= ranges[i][1] {
+				in = true
+				break
+			}
+		}
+		return in != negate
+	}, nil
+}
@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadPluralRules(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "apples.one=%d apple\napples.other=%d apples\n",
+		"xx": "apples.zero=no apples\napples.one=%d apple\napples.few=%d apples (few)\n" +
+			"apples.other=%d apples (other)\n",
+	})
+	defer reset()
+
+	rules := `
+# custom language
+xx zero: n = 0
+xx one: n = 1
+xx few: n % 10 = 2..4, 7 and n mod 100 != 12..14
+# english overridden: one for 1 and 21, 31...
+en one: n = 1 or n % 10 = 1 and n % 100 != 11
+`
+	if err := LoadPluralRules(strings.NewReader(rules)); err != nil {
+		t.Fatalf("load rules: %s", err)
+	}
+
+	table := []struct {
+		Lang     string
+		Count    int
+		Expected string
+	}{
+		{"xx", 0, "no apples"},
+		{"xx", 1, "1 apple"},
+		{"xx", 3, "3 apples (few)"},
+		{"xx", 7, "7 apples (few)"},
+		{"xx", 13, "13 apples (other)"},
+		{"xx", 22, "22 apples (few)"},
+		{"xx-YY", 5, "5 apples (other)"},
+		{"en", 21, "21 apple"},
+		{"en", 11, "11 apples"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Plural(x.Lang, "apples", x.Count); s != x.Expected {
+			t.Errorf("%s %d expected %q, got %q", x.Lang, x.Count, x.Expected, s)
+		}
+	}
+
+	invalid := []string{
+		"xx one n = 1",
+		"xx unknown: n = 1",
+		"xx one: n ~ 1",
+		"xx one: x = 1",
+		"xx one: n % 0 = 1",
+		"xx one: n = a..b",
+	}
+	for _, rule := range invalid {
+		if err := LoadPluralRules(strings.NewReader(rule)); err == nil {
+			t.Errorf("expected error for %q", rule)
+		}
+	}
+}
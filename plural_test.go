@@ -0,0 +1,155 @@
+package i18n
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+)
+
+func TestPlural(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "inbox.one=%d message\ninbox.other=%d messages\n",
+		"fr": "inbox.one=%d message\ninbox.other=%d messages\n",
+		"ru": "inbox.one=%d сообщение\ninbox.few=%d сообщения\ninbox.many=%d сообщений\n" +
+			"inbox.other=%d сообщения\n",
+		"ja": "inbox.other=%d件のメッセージ\n",
+	})
+
+	table := []struct {
+		Lang     string
+		Count    int
+		Expected string
+	}{
+		{"en", 1, "1 message"},
+		{"en", 0, "0 messages"},
+		{"en", 2, "2 messages"},
+		{"fr", 0, "0 message"},
+		{"fr", 2, "2 messages"},
+		{"ru", 1, "1 сообщение"},
+		{"ru", 3, "3 сообщения"},
+		{"ru", 5, "5 сообщений"},
+		{"ru", 11, "11 сообщений"},
+		{"ru", 21, "21 сообщение"},
+		{"ja", 1, "1件のメッセージ"},
+		{"es", 1, "1 message"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Plural(x.Lang, "inbox", x.Count); s != x.Expected {
+			t.Errorf("%s %d expected %q, got %q", x.Lang, x.Count, x.Expected, s)
+		}
+	}
+	if s := Plural("en", "inbox", 3, 30); s != "30 messages" {
+		t.Fatalf("expected explicit args, got %q", s)
+	}
+	if s := Plural("en", "none", 3); s != "none" {
+		t.Fatalf("expected key on miss, got %q", s)
+	}
+}
+
+func TestPluralSelect(t *testing.T) {
+	setup(t, "es", map[string]string{
+		"es": "new.male.one=%d nuevo mensaje\nnew.male.other=%d nuevos mensajes\n" +
+			"new.female.one=%d nueva amiga\nnew.female.other=%d nuevas amigas\n" +
+			"new.one=%d novedad\nnew.other=%d novedades\n" +
+			"partial.one=%d elemento\npartial.female.other=%d elementos nuevas\npartial.other=%d elementos\n",
+	})
+
+	table := []struct {
+		Key      string
+		Count    int
+		Gender   string
+		Expected string
+	}{
+		{"new", 1, "male", "1 nuevo mensaje"},
+		{"new", 2, "male", "2 nuevos mensajes"},
+		{"new", 1, "female", "1 nueva amiga"},
+		{"new", 2, "female", "2 nuevas amigas"},
+		{"new", 1, "other", "1 novedad"},
+		{"new", 5, "", "5 novedades"},
+		{"partial", 1, "female", "1 elemento"},
+		{"partial", 3, "female", "3 elementos nuevas"},
+		{"partial", 3, "male", "3 elementos"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PluralSelect("es-MX", x.Key, x.Count, x.Gender); s != x.Expected {
+			t.Errorf("%s %d %s expected %q, got %q", x.Key, x.Count, x.Gender, x.Expected, s)
+		}
+	}
+}
+
+func TestPluralCategory(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "a=a\n",
+	})
+	table := []struct {
+		Lang     string
+		N        int
+		Expected string
+	}{
+		{"en", 1, PluralOne},
+		{"en", 0, PluralOther},
+		{"fr", 0, PluralOne},
+		{"ja", 1, PluralOther},
+		{"ru", 21, PluralOne},
+		{"ru", 3, PluralFew},
+		{"ru", 11, PluralMany},
+		{"pl", 22, PluralFew},
+		{"pl", 25, PluralMany},
+		{"ar", 0, PluralZero},
+		{"ar", 2, PluralTwo},
+		{"ar", 105, PluralFew},
+		{"ar", 111, PluralMany},
+		{"ar", 100, PluralOther},
+		{"ro", 1, PluralOne},
+		{"ro", 0, PluralFew},
+		{"ro", 101, PluralFew},
+		{"ro", 119, PluralFew},
+		{"ro", 120, PluralOther},
+		{"es-MX", -1, PluralOne},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PluralCategory(x.Lang, x.N); s != x.Expected {
+			t.Errorf("%s:%d expected %q, got %q", x.Lang, x.N, x.Expected, s)
+		}
+	}
+}
+
+func TestPluralCount(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "items.one=%d item\nitems.other=%d items\n",
+		"ru": "items.one=%d файл\nitems.few=%d файла\nitems.many=%d файлов\n",
+	})
+	list := []string{"a", "b", "c"}
+	table := []struct {
+		Lang     string
+		Items    interface{}
+		Expected string
+	}{
+		{"en", list, "3 items"},
+		{"en", &list, "3 items"},
+		{"en", [1]int{7}, "1 item"},
+		{"en", map[string]int{}, "0 items"},
+		{"ru", map[int]bool{1: true, 2: true}, "2 файла"},
+		{"en", 42, "1 item"},
+		{"en", nil, "1 item"},
+		{"en", []int(nil), "0 items"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PluralCount(x.Lang, "items", x.Items); s != x.Expected {
+			t.Errorf("%s:%v expected %q, got %q", x.Lang, x.Items, x.Expected, s)
+		}
+	}
+
+	tmpl := template.Must(template.New("").Funcs(FuncMap).Parse(`{{i18ncount .Lang "items" .Items}}`))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}{"Lang": "en", "Items": list}); err != nil {
+		t.Fatalf("execute: %s", err)
+	}
+	if buf.String() != "3 items" {
+		t.Fatalf("expected 3 items, got %q", buf.String())
+	}
+}
@@ -0,0 +1,58 @@
+package i18n
+
+import "strings"
+
+var (
+	// keyPrefix is stripped from lookup keys.
+	keyPrefix string
+
+	// keyResolver rewrites lookup keys, nil means no rewrite.
+	keyResolver func(lang, key string) string
+
+	// keySeparators replaces key path separators, see KeySeparators.
+	keySeparators *strings.Replacer
+)
+
+// SetKeyPrefix sets a prefix stripped from keys before looking them up, so
+// code calling Println(lang, "app.home.title") resolves home.title from
+// catalog files. Keys not starting with prefix are looked up unchanged.
+//
+// Prefix only affects lookups, loaded keys are stored as written in files.
+// Empty prefix disables stripping.
+func SetKeyPrefix(prefix string) {
+	mut.Lock()
+	defer mut.Unlock()
+	keyPrefix = prefix
+}
+
+// SetKeyResolver sets fn to rewrite keys before looking them up, e.g. to
+// redirect a deprecated key:
+//
+//	i18n.SetKeyResolver(func(lang, key string) string {
+//		if key == "home.old_title" {
+//			return "home.title"
+//		}
+//		return key
+//	})
+//
+// fn runs before SetKeyPrefix stripping, while the catalog is locked, it
+// must not call i18n funcs. nil disables rewriting.
+func SetKeyResolver(fn func(lang, key string) string) {
+	mut.Lock()
+	defer mut.Unlock()
+	keyResolver = fn
+}
+
+// normalizeKey returns key as stored in catalog. Caller must hold mut.
+func normalizeKey(lang, key string) string {
+	if keyResolver != nil {
+		key = keyResolver(lang, key)
+	}
+	if keyPrefix != "" && strings.HasPrefix(key, keyPrefix) {
+		key = key[len(keyPrefix):]
+	}
+	if keySeparators != nil {
+		key = keySeparators.Replace(key)
+	}
+	return key
+}
@@ -0,0 +1,77 @@
+package i18n
+
+import "testing"
+
+func TestSetKeyPrefix(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Home\ncta.1=Buy\n",
+		"es": "home.title=Inicio\n",
+	})
+	defer reset()
+	SetKeyPrefix("app.")
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"es", "app.home.title", "Inicio"},
+		{"es", "home.title", "Inicio"},
+		{"en", "app.home.subtitle", "app.home.subtitle"},
+		{"en", "other.home.title", "other.home.title"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+	if s := Variant("en", "app.cta"); s != "Buy" {
+		t.Fatalf("expected prefix stripped from variant, got %q", s)
+	}
+	if s := PrintlnFallbackKey("es", "app.home.heading", "app.home.title"); s != "Inicio" {
+		t.Fatalf("expected prefix stripped from fallback key, got %q", s)
+	}
+}
+
+func TestSetKeyResolver(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Home\nhome.old_title=Old home\n",
+		"es": "home.title=Inicio\nhome.old_title=Inicio viejo\n",
+	})
+	defer reset()
+
+	if s := Println("es", "home.old_title"); s != "Inicio viejo" {
+		t.Fatalf("expected no resolver by default, got %q", s)
+	}
+
+	SetKeyPrefix("app.")
+	SetKeyResolver(func(lang, key string) string {
+		if key == "app.home.old_title" && lang != "en" {
+			return "app.home.title"
+		}
+		return key
+	})
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"es", "app.home.old_title", "Inicio"},
+		{"es", "home.old_title", "Inicio viejo"},
+		{"en", "app.home.old_title", "Old home"},
+		{"es", "app.home.title", "Inicio"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+
+	SetKeyResolver(nil)
+	if s := Println("es", "app.home.old_title"); s != "Inicio viejo" {
+		t.Fatalf("expected resolver disabled, got %q", s)
+	}
+}
@@ -0,0 +1,205 @@
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// ExportProperties writes keys of lang as a Java .properties file sorted by
+// key. Non ASCII characters are written as \uXXXX escapes, separators,
+// comment symbols, backslashes and control characters are escaped like
+// java.util.Properties store does. It doesn't follow the fallback chain.
+func ExportProperties(w io.Writer, lang string) error {
+	list, values := keyValues(lang)
+
+	bw := bufio.NewWriter(w)
+	for i := range list {
+		fmt.Fprintf(bw, "%s=%s\n", escapeProperty(list[i], true), escapeProperty(values[i], false))
+	}
+	return bw.Flush()
+}
+
+// RoundTrip exports lang with ExportProperties and parses the output back,
+// returning an error listing keys whose value or key didn't survive the
+// cycle. Useful in tests guarding escaping of tricky values.
+func RoundTrip(lang string) error {
+	var b bytes.Buffer
+	if err := ExportProperties(&b, lang); err != nil {
+		return err
+	}
+	parsed, err := parseProperties(&b)
+	if err != nil {
+		return err
+	}
+
+	mut.RLock()
+	defer mut.RUnlock()
+	var errs errorList
+	for _, key := range keys(lang) {
+		v := langs[bullet(lang, key)]
+		got, ok := parsed[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("key %q lost", key))
+			continue
+		}
+		if got != v {
+			errs = append(errs, fmt.Errorf("key %q changed: %q != %q", key, got, v))
+		}
+		delete(parsed, key)
+	}
+	for key := range parsed {
+		errs = append(errs, fmt.Errorf("unexpected key %q", key))
+	}
+	if len(errs) > 0 {
+		sort.Sort(errs)
+		s := make([]string, len(errs))
+		for i := range errs {
+			s[i] = errs[i].Error()
+		}
+		return fmt.Errorf("i18n: round trip %s: %s", lang, strings.Join(s, "; "))
+	}
+	return nil
+}
+
+// parseProperties reads key=value lines written by ExportProperties.
+func parseProperties(r io.Reader) (map[string]string, error) {
+	m := make(map[string]string)
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		line := scan.Text()
+		i := separatorIndex(line)
+		if i < 0 {
+			return nil, fmt.Errorf("i18n: malformed properties line %q", line)
+		}
+		key, err := unescapeProperty(line[:i])
+		if err != nil {
+			return nil, err
+		}
+		value, err := unescapeProperty(line[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		m[key] = value
+	}
+	return m, scan.Err()
+}
+
+// separatorIndex returns the index of the first unescaped = in line, -1 if
+// not found.
+func separatorIndex(line string) int {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=':
+			return i
+		}
+	}
+	return -1
+}
+
+// escapeProperty escapes s for a .properties file, all spaces are escaped
+// for keys, only leading space for values.
+func escapeProperty(s string, key bool) string {
+	var b bytes.Buffer
+	for i, r := range s {
+		switch r {
+		case ' ':
+			if key || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(' ')
+		case '\\', '=', ':', '#', '!':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r >= 0x20 && r < 0x7f {
+				b.WriteRune(r)
+				continue
+			}
+			if r > 0xffff {
+				r1, r2 := utf16.EncodeRune(r)
+				fmt.Fprintf(&b, `\u%04X\u%04X`, r1, r2)
+				continue
+			}
+			fmt.Fprintf(&b, `\u%04X`, r)
+		}
+	}
+	return b.String()
+}
+
+// unescapeProperty reverts escapeProperty.
+func unescapeProperty(s string) (string, error) {
+	var u []uint16
+	var b bytes.Buffer
+	flush := func() {
+		if len(u) > 0 {
+			b.WriteString(string(utf16.Decode(u)))
+			u = u[:0]
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			flush()
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("i18n: malformed \\u escape in %q", s)
+			}
+			n, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("i18n: malformed \\u escape in %q", s)
+			}
+			u = append(u, uint16(n))
+			i += 4
+			continue
+		case 't':
+			flush()
+			b.WriteByte('\t')
+		case 'n':
+			flush()
+			b.WriteByte('\n')
+		case 'r':
+			flush()
+			b.WriteByte('\r')
+		case 'f':
+			flush()
+			b.WriteByte('\f')
+		default:
+			flush()
+			b.WriteByte(s[i])
+		}
+	}
+	flush()
+	return b.String(), nil
+}
+
+// keyValues returns sorted keys of lang and their values.
+func keyValues(lang string) ([]string, []string) {
+	mut.RLock()
+	defer mut.RUnlock()
+	list := keys(lang)
+	values := make([]string, len(list))
+	for i := range list {
+		values[i] = langs[bullet(lang, list[i])]
+	}
+	return list, values
+}
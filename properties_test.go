@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExportProperties(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"es": "home.title=Menú de inicio\nsum=a=b: c # d!\npath=C:\\dir\nemoji= 😀 ok\n",
+		"en": "home.title=Home\n",
+	})
+	var buf bytes.Buffer
+	if err := ExportProperties(&buf, "es"); err != nil {
+		t.Fatalf("export: %s", err)
+	}
+	expected := `emoji=\ \uD83D\uDE00 ok` + "\n" +
+		`home.title=Men\u00FA de inicio` + "\n" +
+		`path=C\:\\dir` + "\n" +
+		`sum=a\=b\: c \# d\!` + "\n"
+	if buf.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	// round trip.
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		i := strings.Index(line, "=")
+		key, err := unescapeProperty(line[:i])
+		if err != nil {
+			t.Fatalf("unescape: %s", err)
+		}
+		value, err := unescapeProperty(line[i+1:])
+		if err != nil {
+			t.Fatalf("unescape: %s", err)
+		}
+		if s := Println("es", key); s != value {
+			t.Errorf("%s expected %q, got %q", key, s, value)
+		}
+	}
+
+	if _, err := unescapeProperty(`bad \u00`); err == nil {
+		t.Fatalf("expected malformed escape error")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	reset()
+	defer reset()
+	dir := writeFiles(t, map[string]string{
+		"es": "sum|a=b: c # d!\nmulti|line one\\nline two\\ttab\npath|C:\\\\dir\\\\\nquote|\"quoted\" 'single'\n" +
+			"space| leading and trailing \nkey with = and : chars|ok\nemoji|😀 ñandú\nempty|\n",
+	})
+	defer os.RemoveAll(dir)
+	if _, err := LoadVerbose(dir, "es", "|", "", Escapes()); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if s := Println("es", "multi"); s != "line one\nline two\ttab" {
+		t.Fatalf("expected escapes converted, got %q", s)
+	}
+	if err := RoundTrip("es"); err != nil {
+		t.Fatalf("round trip: %s", err)
+	}
+
+	// invalid utf-8 is exported as U+FFFD.
+	mut.Lock()
+	langs["es:binary"] = "\xff"
+	mut.Unlock()
+	expected := "i18n: round trip es: key \"binary\" changed: \"\ufffd\" != \"\\xff\""
+	if err := RoundTrip("es"); err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+	parsed, err := parseProperties(strings.NewReader("a\\=b=c\\\\=d\n"))
+	if err != nil || parsed["a=b"] != "c\\=d" {
+		t.Fatalf("unexpected parse %v %v", parsed, err)
+	}
+	if _, err := parseProperties(strings.NewReader("no separator\n")); err == nil {
+		t.Fatalf("expected malformed line error")
+	}
+}
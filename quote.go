@@ -0,0 +1,49 @@
+package i18n
+
+import "strings"
+
+// quotes contains primary quotation marks by language.
+var quotes = map[string][2]string{
+	"en":    {"“", "”"},
+	"es":    {"«", "»"},
+	"pt":    {"“", "”"},
+	"pt-pt": {"«", "»"},
+	"fr":    {"«\u00a0", "\u00a0»"},
+	"de":    {"„", "“"},
+	"de-ch": {"«", "»"},
+	"it":    {"«", "»"},
+	"nl":    {"‘", "’"},
+	"ru":    {"«", "»"},
+	"uk":    {"«", "»"},
+	"pl":    {"„", "”"},
+	"cs":    {"„", "“"},
+	"sv":    {"”", "”"},
+	"fi":    {"”", "”"},
+	"ja":    {"「", "」"},
+	"zh":    {"“", "”"},
+	"ko":    {"“", "”"},
+}
+
+// Quote returns s wrapped in lang primary quotation marks, e.g. „s“ for de
+// and « s » for fr (with no-break spaces). Languages without data use
+// english quotes. In templates:
+//
+//	{{i18nquote .Lang .UserText}}
+func Quote(lang, s string) string {
+	q := quotesFor(lang)
+	return q[0] + s + q[1]
+}
+
+// quotesFor returns quotation marks for lang, its base language or english.
+func quotesFor(lang string) [2]string {
+	lang = cleanLang(lang)
+	if q, ok := quotes[lang]; ok {
+		return q
+	}
+	if i := strings.IndexAny(lang, "-_"); i > -1 {
+		if q, ok := quotes[lang[:i]]; ok {
+			return q
+		}
+	}
+	return quotes["en"]
+}
@@ -0,0 +1,42 @@
+package i18n
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	table := []struct {
+		Lang     string
+		Input    string
+		Expected string
+	}{
+		{"en", "hello", "“hello”"},
+		{"en-GB", "hello", "“hello”"},
+		{"de", "Hallo", "„Hallo“"},
+		{"de-AT", "Hallo", "„Hallo“"},
+		{"de-CH", "Hallo", "«Hallo»"},
+		{"fr", "Bonjour", "«\u00a0Bonjour\u00a0»"},
+		{"fr_CA", "Bonjour", "«\u00a0Bonjour\u00a0»"},
+		{"xx", "hi", "“hi”"},
+		{"", "", "“”"},
+	}
+	for _, x := range table {
+		if s := Quote(x.Lang, x.Input); s != x.Expected {
+			t.Errorf("%s:%q expected %q, got %q", x.Lang, x.Input, x.Expected, s)
+		}
+	}
+}
+
+func TestQuoteFuncMap(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(FuncMap).Parse(`{{i18nquote .Lang .UserText}}`))
+	var b bytes.Buffer
+	err := tmpl.Execute(&b, map[string]string{"Lang": "de", "UserText": "<b>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := b.String(); s != "„&lt;b&gt;“" {
+		t.Fatalf("expected escaped quoted text, got %q", s)
+	}
+}
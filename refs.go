@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"bytes"
+	"strings"
+)
+
+// maxRefDepth limits nested {@key} references expansion.
+const maxRefDepth = 5
+
+const refOpen = "{@"
+
+// expandRefs replaces {@key} references in v with key translation for lang
+// using the usual fallback chain:
+//
+//	role.admin=administrator
+//	welcome=Hello, {@role.admin}!
+//
+// Missing keys and references nested deeper than maxRefDepth are kept
+// verbatim. Caller must hold mut.
+func expandRefs(lang, v string, depth int) string {
+	if !strings.Contains(v, refOpen) {
+		return v
+	}
+	var buf bytes.Buffer
+	for {
+		i := strings.Index(v, refOpen)
+		if i < 0 {
+			break
+		}
+		j := strings.Index(v[i:], "}")
+		if j < 0 {
+			break
+		}
+		buf.WriteString(v[:i])
+		ref := v[i : i+j+1]
+		v = v[i+j+1:]
+
+		key := strings.TrimSpace(ref[len(refOpen) : len(ref)-1])
+		if depth >= maxRefDepth || key == "" {
+			buf.WriteString(ref)
+			continue
+		}
+		s, l, ok := resolveKey(lang, key)
+		if ok {
+			s, _, ok = follow(lang, s, l)
+		}
+		if !ok {
+			buf.WriteString(ref)
+			continue
+		}
+		buf.WriteString(expandRefs(lang, s, depth+1))
+	}
+	buf.WriteString(v)
+	return buf.String()
+}
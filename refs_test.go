@@ -0,0 +1,31 @@
+package i18n
+
+import "testing"
+
+func TestExpandRefs(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "role.admin=administrator\nwelcome=Hello, {@role.admin}!\n" +
+			"brand=Acme\nfooter={@ brand } by {@brand}, {@none}\n" +
+			"loop.a=a{@loop.b}\nloop.b=b{@loop.a}\nopen=Hi {@role.admin\n" +
+			"linked=@alias(welcome)\n",
+		"es": "role.admin=administrador\nwelcome=Hola, {@role.admin}!\n",
+	})
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "welcome", "Hello, administrator!"},
+		{"es-MX", "welcome", "Hola, administrador!"},
+		{"es", "footer", "Acme by Acme, {@none}"},
+		{"en", "loop.a", "ababab{@loop.a}"},
+		{"en", "open", "Hi {@role.admin"},
+		{"es", "linked", "Hola, administrador!"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
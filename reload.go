@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"path"
+	"strings"
+)
+
+// ReloadKeys reloads from dir only keys matching pattern, other keys are
+// left untouched. Matching keys missing in dir are removed.
+//
+// pattern is a glob (see path.Match) if it contains any of *?[ otherwise
+// it's a key prefix.
+func ReloadKeys(pattern, dir, separator, comment string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return err
+	}
+	o := newOptions(nil)
+	m, err := parseDir(dir, separator, comment, o)
+	if err != nil {
+		return err
+	}
+
+	mut.Lock()
+	defer mut.Unlock()
+	invalidate()
+	for slug := range langs {
+		if matchKey(pattern, slug) {
+			delete(langs, slug)
+			delete(sources, slug)
+		}
+	}
+	for slug, value := range m {
+		if matchKey(pattern, slug) {
+			langs[slug] = value
+			sources[slug] = o.sources[slug]
+		}
+	}
+	return nil
+}
+
+// matchKey reports if key of slug (lang:key) matches pattern.
+func matchKey(pattern, slug string) bool {
+	key := slug[strings.Index(slug, ":")+1:]
+	if !strings.ContainsAny(pattern, "*?[") {
+		return strings.HasPrefix(key, pattern)
+	}
+	ok, _ := path.Match(pattern, key)
+	return ok
+}
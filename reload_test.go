@@ -0,0 +1,51 @@
+package i18n
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReloadKeys(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "home.title=Home\nhome.subtitle=Welcome\nmenu.file=File\n",
+		"es": "home.title=Inicio\nmenu.file=Archivo\n",
+	})
+	dir := writeFiles(t, map[string]string{
+		"en": "home.title=Home v2\nmenu.file=File v2\n",
+		"es": "home.title=Inicio v2\nhome.subtitle=Bienvenido\nmenu.file=Archivo v2\n",
+	})
+	defer os.RemoveAll(dir)
+
+	if err := ReloadKeys("home.", dir, "", ""); err != nil {
+		t.Fatalf("reload: %s", err)
+	}
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "home.title", "Home v2"},
+		{"es", "home.title", "Inicio v2"},
+		{"es", "home.subtitle", "Bienvenido"},
+		{"en", "home.subtitle", "home.subtitle"},
+		{"en", "menu.file", "File"},
+		{"es", "menu.file", "Archivo"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+
+	if err := ReloadKeys("menu.*", dir, "", ""); err != nil {
+		t.Fatalf("reload: %s", err)
+	}
+	if s := Println("es", "menu.file"); s != "Archivo v2" {
+		t.Fatalf("expected glob reload, got %q", s)
+	}
+
+	if err := ReloadKeys("[", dir, "", ""); err == nil {
+		t.Fatalf("expected bad pattern error")
+	}
+}
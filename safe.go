@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"html/template"
+	"time"
+)
+
+// Template funcs recovering from panics, see FuncMap.
+
+func safePrintln(lang, key string) (s string) {
+	defer recoverKey("i18n", key, &s)
+	return Println(lang, key)
+}
+
+func safePrintf(lang, key string, args ...interface{}) (s string) {
+	defer recoverKey("i18nf", key, &s)
+	return Printf(lang, key, args...)
+}
+
+func safeDuration(lang string, d time.Duration) (s string) {
+	defer recoverKey("i18ndur", "", &s)
+	return FormatDuration(lang, d)
+}
+
+func safeBR(lang, key string) (s template.HTML) {
+	defer recoverHTML("i18nbr", key, &s)
+	return PrintlnBR(lang, key)
+}
+
+func safeCount(lang, key string, items interface{}, args ...interface{}) (s string) {
+	defer recoverKey("i18ncount", key, &s)
+	return PluralCount(lang, key, items, args...)
+}
+
+func safePlain(lang, key string) (s string) {
+	defer recoverKey("i18nplain", key, &s)
+	return PrintlnNoMnemonic(lang, key)
+}
+
+func safeTruncate(lang, key string, maxRunes int) (s string) {
+	defer recoverKey("i18ntrunc", key, &s)
+	return Truncate(lang, key, maxRunes)
+}
+
+func safeCapitalize(lang, s string) (res string) {
+	defer recoverKey("i18ncap", s, &res)
+	return CapitalizeFirst(lang, s)
+}
+
+func safeQuote(lang, s string) (res string) {
+	defer recoverKey("i18nquote", s, &res)
+	return Quote(lang, s)
+}
+
+// recoverKey sets s to key on panic, it must be deferred.
+func recoverKey(name, key string, s *string) {
+	if r := recover(); r != nil {
+		logf("i18n: %s %q: recovered panic: %v", name, key, r)
+		*s = key
+	}
+}
+
+// recoverHTML sets s to key HTML escaped on panic, it must be deferred.
+func recoverHTML(name, key string, s *template.HTML) {
+	if r := recover(); r != nil {
+		logf("i18n: %s %q: recovered panic: %v", name, key, r)
+		*s = template.HTML(template.HTMLEscapeString(key))
+	}
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplateFuncsRecover(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "hello=Hello\nitems.other=%d items\n",
+	})
+	defer reset()
+	var logs bytes.Buffer
+	SetLogger(log.New(&logs, "", 0))
+	defer SetLogger(nil)
+	SetKeyResolver(func(lang, key string) string {
+		if strings.HasPrefix(key, "<boom") {
+			panic("resolver failed")
+		}
+		return key
+	})
+
+	fnmap := ReutilizeFuncMap(FuncMapCtx(WithLang(context.Background(), "en")))
+	tmpl, err := template.New("page").Funcs(fnmap).Parse(
+		`{{i18n "en" "<boom>"}}|{{i18nf "en" "<boom>" 1}}|{{i18nbr "en" "<boom>"}}|` +
+			`{{i18ncount "en" "<boom>" .}}|{{t "<boom>"}}|{{tf "<boom>" 2}}|` +
+			`{{i18ndur "en" .D}}|{{i18n "en" "hello"}}`)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}{"D": time.Minute}); err != nil {
+		t.Fatalf("execute: %s", err)
+	}
+	expected := "&lt;boom&gt;|&lt;boom&gt;|&lt;boom&gt;|&lt;boom&gt;|&lt;boom&gt;|&lt;boom&gt;|1 minute|Hello"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+	if n := strings.Count(logs.String(), "recovered panic: resolver failed"); n != 6 {
+		t.Fatalf("expected 6 logged panics, got %d:\n%s", n, logs.String())
+	}
+
+	// catalog lock is released after a panic.
+	SetKeyResolver(nil)
+	if s := Println("en", "hello"); s != "Hello" {
+		t.Fatalf("expected Hello, got %q", s)
+	}
+}
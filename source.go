@@ -0,0 +1,14 @@
+package i18n
+
+// sources contains the file path each lang:key was loaded from.
+var sources = make(map[string]string)
+
+// SourceFile returns the path of the file lang+key was loaded from, the
+// included file for keys inlined by @include. It doesn't follow the
+// fallback chain, false is returned if lang doesn't contain key.
+func SourceFile(lang, key string) (string, bool) {
+	mut.RLock()
+	defer mut.RUnlock()
+	name, ok := sources[bullet(lang, key)]
+	return name, ok
+}
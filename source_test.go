@@ -0,0 +1,71 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSourceFile(t *testing.T) {
+	reset()
+	dir := writeFiles(t, map[string]string{
+		"en":          "home.title=Home\n",
+		"es":          "home.title=Inicio\n",
+		"latam/es-MX": "home.title=Inicio MX\n",
+	})
+	defer os.RemoveAll(dir)
+	if err := Load(dir, "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+
+	table := []struct {
+		Lang     string
+		Expected string
+	}{
+		{"en", filepath.Join(dir, "en")},
+		{"es", filepath.Join(dir, "es")},
+		{"es-MX", filepath.Join(dir, "latam", "es-MX")},
+	}
+	for i := range table {
+		x := table[i]
+		name, ok := SourceFile(x.Lang, "home.title")
+		if !ok || name != x.Expected {
+			t.Errorf("%s expected %q, got %q %v", x.Lang, x.Expected, name, ok)
+		}
+	}
+	if _, ok := SourceFile("fr", "home.title"); ok {
+		t.Fatalf("expected no source for missing language")
+	}
+}
+
+func TestSourceFileInclude(t *testing.T) {
+	reset()
+	defer reset()
+	inc := writeFiles(t, map[string]string{"common.inc": "shared=Shared\n"})
+	defer os.RemoveAll(inc)
+	common := filepath.Join(inc, "common.inc")
+	dir := writeFiles(t, map[string]string{
+		"en": "home=Home\n@include " + common + "\n",
+	})
+	defer os.RemoveAll(dir)
+	if err := Load(dir, "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+
+	if name, _ := SourceFile("en", "home"); name != filepath.Join(dir, "en") {
+		t.Fatalf("expected including file, got %q", name)
+	}
+	name, ok := SourceFile("en", "shared")
+	if !ok || name != common {
+		t.Fatalf("expected included file %q, got %q", common, name)
+	}
+	if err := RewriteFile(name, "shared", "Common"); err != nil {
+		t.Fatalf("rewrite: %s", err)
+	}
+	if err := Load(dir, "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if s := Println("en", "shared"); s != "Common" {
+		t.Fatalf("expected rewritten value, got %q", s)
+	}
+}
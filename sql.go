@@ -0,0 +1,59 @@
+package i18n
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// LoadSQL loads translations from db, query must return rows of lang, key
+// and value columns:
+//
+//	err := i18n.LoadSQL(db, "SELECT lang, key, value FROM translations")
+//
+// Rows are merged into the catalog like Load does, the default language is
+// left untouched. Overwritten values lose their file @attribution and
+// @deprecated directives. Rows failing to scan are skipped and reported in err,
+// other rows are loaded anyway.
+func LoadSQL(db *sql.DB, query string) error {
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	m := make(map[string]string)
+	var skipped []string
+	for i := 1; rows.Next(); i++ {
+		var lang, key, value string
+		if err := rows.Scan(&lang, &key, &value); err != nil {
+			skipped = append(skipped, fmt.Sprintf("row %d: %s", i, err))
+			continue
+		}
+		m[bullet(lang, key)] = value
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	loadValues(m)
+
+	if len(skipped) > 0 {
+		return fmt.Errorf("i18n: sql: skipped rows: %s", strings.Join(skipped, ", "))
+	}
+	return nil
+}
+
+// loadValues merges values by lang:key into the catalog dropping file
+// metadata of overwritten values.
+func loadValues(m map[string]string) {
+	mut.Lock()
+	defer mut.Unlock()
+	invalidate()
+	for slug, value := range m {
+		langs[slug] = value
+		delete(sources, slug)
+		delete(attributions, slug)
+		delete(deprecated, slug[strings.Index(slug, ":")+1:])
+	}
+}
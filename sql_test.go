@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fakeDriver serves rows of fakeRows for any query.
+type fakeDriver struct{}
+
+var fakeRows [][]driver.Value
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	if query == "" {
+		return nil, errors.New("empty query")
+	}
+	return fakeStmt{}, nil
+}
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeResult{rows: fakeRows}, nil
+}
+
+type fakeResult struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeResult) Columns() []string { return []string{"lang", "key", "value"} }
+func (r *fakeResult) Close() error      { return nil }
+func (r *fakeResult) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("i18nfake", fakeDriver{})
+}
+
+func TestLoadSQL(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "hello=Hello\n# @attribution=CC BY 4.0 Jane Doe\n# @deprecated farewell\nbye=Bye\n",
+	})
+	defer reset()
+	db, err := sql.Open("i18nfake", "")
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+	defer db.Close()
+
+	fakeRows = [][]driver.Value{
+		{"es", "hello", "Hola"},
+		{"en", "bye", "Goodbye"},
+		{"es", "broken", nil},
+		{"es-MX", "hello", []byte("Qué onda")},
+	}
+	err = LoadSQL(db, "SELECT lang, key, value FROM translations")
+	if err == nil || !strings.Contains(err.Error(), "row 3") {
+		t.Fatalf("expected row 3 scan error, got %v", err)
+	}
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"es", "hello", "Hola"},
+		{"es-MX", "hello", "Qué onda"},
+		{"es", "bye", "Goodbye"},
+		{"en", "hello", "Hello"},
+		{"es", "broken", "broken"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+	if _, ok := SourceFile("en", "bye"); ok {
+		t.Fatalf("expected no source file for sql value")
+	}
+	if s := Attribution("en", "bye"); s != "" {
+		t.Fatalf("expected no attribution for sql value, got %q", s)
+	}
+	if _, ok := deprecated["bye"]; ok {
+		t.Fatalf("expected sql value not deprecated")
+	}
+
+	fakeRows = nil
+	if err := LoadSQL(db, ""); err == nil {
+		t.Fatalf("expected query error")
+	}
+}
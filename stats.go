@@ -0,0 +1,35 @@
+package i18n
+
+import "strings"
+
+// CatalogStats contains catalog size information.
+type CatalogStats struct {
+	// Languages is the number of loaded languages.
+	Languages int
+	// Keys is the number of distinct keys.
+	Keys int
+	// Entries is the number of lang+key values.
+	Entries int
+	// Bytes is an estimate of memory used by keys and values. It doesn't
+	// account for map overhead.
+	Bytes int
+}
+
+// Stats returns catalog size information.
+func Stats() CatalogStats {
+	mut.RLock()
+	defer mut.RUnlock()
+	languages := make(map[string]struct{})
+	keys := make(map[string]struct{})
+	var st CatalogStats
+	for slug, value := range langs {
+		i := strings.Index(slug, ":")
+		languages[slug[:i]] = struct{}{}
+		keys[slug[i+1:]] = struct{}{}
+		st.Bytes += len(slug) + len(value)
+	}
+	st.Languages = len(languages)
+	st.Keys = len(keys)
+	st.Entries = len(langs)
+	return st
+}
@@ -0,0 +1,21 @@
+package i18n
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "a=One\nbb=Two\n",
+		"es": "a=Uno\nccc=Tres\n",
+	})
+
+	expected := CatalogStats{
+		Languages: 2,
+		Keys:      3,
+		Entries:   4,
+		// en:a One, en:bb Two, es:a Uno, es:ccc Tres
+		Bytes: 4 + 3 + 5 + 3 + 4 + 3 + 6 + 4,
+	}
+	if st := Stats(); st != expected {
+		t.Fatalf("expected %+v, got %+v", expected, st)
+	}
+}
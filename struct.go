@@ -0,0 +1,52 @@
+package i18n
+
+import "reflect"
+
+// LocalizeStruct returns a map of field name to translated label for fields
+// of struct v tagged with i18n:
+//
+//	type Form struct {
+//		Name string `i18n:"field.name"`
+//	}
+//
+// Fields of embedded structs are included, untagged fields are skipped.
+// v can be a struct or a pointer to struct, otherwise the map is empty.
+func LocalizeStruct(lang string, v interface{}) map[string]string {
+	m := make(map[string]string)
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return m
+	}
+	localizeFields(lang, rv.Type(), m, make(map[reflect.Type]bool))
+	return m
+}
+
+// localizeFields adds labels of t fields to m, visited contains struct types
+// already walked so self embedding types don't recurse forever.
+func localizeFields(lang string, t reflect.Type, m map[string]string, visited map[reflect.Type]bool) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := f.Tag.Get("i18n")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && ft.Kind() == reflect.Struct {
+				localizeFields(lang, ft, m, visited)
+			}
+			continue
+		}
+		m[f.Name] = Println(lang, key)
+	}
+}
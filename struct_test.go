@@ -0,0 +1,68 @@
+package i18n
+
+import "testing"
+
+type testAudit struct {
+	Created string `i18n:"field.created"`
+}
+
+type testForm struct {
+	*testAudit
+	Name    string `i18n:"field.name"`
+	Email   string `i18n:"field.email"`
+	Ignored string `i18n:"-"`
+	Plain   string
+}
+
+// testNode embeds a pointer to its own type.
+type testNode struct {
+	*testNode
+	*testLeaf
+	Label string `i18n:"field.name"`
+}
+
+type testLeaf struct {
+	*testNode
+	Created string `i18n:"field.created"`
+}
+
+func TestLocalizeStruct(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "field.name=Name\nfield.email=Email\nfield.created=Created\n",
+		"es": "field.name=Nombre\nfield.created=Creado\n",
+	})
+
+	m := LocalizeStruct("es", &testForm{})
+	expected := map[string]string{
+		"Name":    "Nombre",
+		"Email":   "Email",
+		"Created": "Creado",
+	}
+	if len(m) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, m)
+	}
+	for k, v := range expected {
+		if m[k] != v {
+			t.Fatalf("field %s expected %q, got %q", k, v, m[k])
+		}
+	}
+
+	if m := LocalizeStruct("es", "not a struct"); len(m) != 0 {
+		t.Fatalf("expected empty map, got %v", m)
+	}
+	var nilForm *testForm
+	if m := LocalizeStruct("es", nilForm); len(m) != 0 {
+		t.Fatalf("expected empty map, got %v", m)
+	}
+}
+
+func TestLocalizeStructCycle(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "field.name=Name\nfield.created=Created\n",
+	})
+
+	m := LocalizeStruct("en", testNode{})
+	if len(m) != 2 || m["Label"] != "Name" || m["Created"] != "Created" {
+		t.Fatalf("unexpected labels %v", m)
+	}
+}
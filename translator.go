@@ -0,0 +1,251 @@
+package i18n
+
+import (
+	"errors"
+	"html/template"
+)
+
+// Translator is a catalog loaded by NewBuilder, independent of the package
+// catalog: building it doesn't change Println, Printf or the Load default
+// language. Lookups fall back to the base language and then the default
+// language, values go through SetPipeline funcs. On misses the SetFetcher
+// func is asked, fetched values aren't cached. It's safe for concurrent use.
+type Translator struct {
+	// values are translations by lang and key.
+	values map[string]map[string]string
+	def    string
+	ns     string
+}
+
+// Default returns the default language the catalog was built with.
+func (t *Translator) Default() string {
+	return t.def
+}
+
+// Println works like Println func.
+func (t *Translator) Println(lang, key string) string {
+	v, _ := t.translate(lang, key)
+	return v
+}
+
+// Printf works like Printf func.
+func (t *Translator) Printf(lang, key string, args ...interface{}) string {
+	v, ok := t.translate(lang, key)
+	if !ok {
+		return v
+	}
+	return sprintf(v, args...)
+}
+
+// translate returns the translation for lang+key asking fetcher on misses,
+// false and the missing key text if not found. Pending translations,
+// fetcher and logs see key inside t namespace.
+func (t *Translator) translate(lang, key string) (string, bool) {
+	v, step, ok := t.lookup(lang, key)
+	if ok {
+		return t.served(lang, key, v, step), true
+	}
+	if fn := t.fetcher(lang, key); fn != nil {
+		if v, ok := fn(lang, t.key(key)); ok {
+			mut.RLock()
+			defer mut.RUnlock()
+			return process(v), true
+		}
+	}
+	mut.RLock()
+	defer mut.RUnlock()
+	logMiss(lang, t.key(key))
+	return missing(key), false
+}
+
+// served returns v found for lang+key by step with the pipeline applied,
+// logging and recording it as pending like package lookups.
+func (t *Translator) served(lang, key, v string, step int) string {
+	mut.RLock()
+	defer mut.RUnlock()
+	t.logServed(lang, key, step)
+	if step == stepDefault {
+		t.recordPending(lang, key)
+	}
+	return process(v)
+}
+
+// fetcher records missing lang+key as pending and returns the SetFetcher
+// func.
+func (t *Translator) fetcher(lang, key string) func(lang, key string) (string, bool) {
+	mut.RLock()
+	defer mut.RUnlock()
+	t.recordPending(lang, key)
+	return fetcher
+}
+
+// Lookup steps of Translator.lookup.
+const (
+	stepLang = iota
+	stepRegion
+	stepDefault
+)
+
+// lookup returns the value of key for lang, its base language or the
+// default language and the step finding it.
+func (t *Translator) lookup(lang, key string) (string, int, bool) {
+	lang = cleanLang(lang)
+	if v, ok := t.values[lang][key]; ok {
+		return v, stepLang, true
+	}
+	if len(lang) > 2 {
+		if v, ok := t.values[lang[:2]][key]; ok {
+			return v, stepRegion, true
+		}
+	}
+	if v, ok := t.values[cleanLang(t.def)][key]; ok {
+		return v, stepDefault, true
+	}
+	return "", 0, false
+}
+
+// logServed logs a lookup of lang+key found by a fallback step like
+// package lookups do. Caller must hold mut.
+func (t *Translator) logServed(lang, key string, step int) {
+	switch {
+	case step == stepRegion && fallbackLog&LogRegion != 0:
+		logf("i18n: lang [%s] key [%s] served by region fallback [%s]", lang, t.key(key), cleanLang(lang)[:2])
+	case step == stepDefault && fallbackLog&LogDefault != 0:
+		logf("i18n: lang [%s] key [%s] served by default language [%s]", lang, t.key(key), cleanLang(t.def))
+	}
+}
+
+// recordPending records lang+key as pending translation with the default
+// language value. Caller must hold mut.
+func (t *Translator) recordPending(lang, key string) {
+	if pendingEnabled {
+		addPending(cleanLang(lang), t.key(key), t.values[cleanLang(t.def)][key])
+	}
+}
+
+// FuncMap returns a copy of FuncMap with translation funcs bound to t.
+func (t *Translator) FuncMap() template.FuncMap {
+	fnmap := ReutilizeFuncMap(make(template.FuncMap, len(FuncMap)))
+	for name, fn := range t.funcs() {
+		fnmap[name] = fn
+	}
+	return fnmap
+}
+
+// FuncMapNamed returns translation funcs bound to t named with prefix, e.g.
+// prefix lib registers lib_i18n, lib_i18nf and lib_i18nbr. Useful to render
+// several catalogs in one template, each Translator resolving its own keys
+// and default language:
+//
+//	fnmap := lib.FuncMapNamed("lib")
+//	for name, fn := range app.FuncMapNamed("app") {
+//		fnmap[name] = fn
+//	}
+func (t *Translator) FuncMapNamed(prefix string) template.FuncMap {
+	fnmap := make(template.FuncMap)
+	for name, fn := range t.funcs() {
+		fnmap[prefix+"_"+name] = fn
+	}
+	return fnmap
+}
+
+// funcs returns FuncMap translation funcs bound to t.
+func (t *Translator) funcs() template.FuncMap {
+	return template.FuncMap{
+		"i18n": func(lang, key string) (s string) {
+			defer recoverKey("i18n", key, &s)
+			return t.Println(lang, key)
+		},
+		"i18nf": func(lang, key string, args ...interface{}) (s string) {
+			defer recoverKey("i18nf", key, &s)
+			return t.Printf(lang, key, args...)
+		},
+		"i18nbr": func(lang, key string) (s template.HTML) {
+			defer recoverHTML("i18nbr", key, &s)
+			return lineBreaks(t.Println(lang, key))
+		},
+	}
+}
+
+// key returns key inside t namespace.
+func (t *Translator) key(key string) string {
+	if t.ns == "" {
+		return key
+	}
+	return t.ns + "." + key
+}
+
+// Builder configures a Translator step by step, an alternative to Load
+// positional arguments:
+//
+//	t, err := i18n.NewBuilder().Dir("locales").Default("en").Strict().Build()
+type Builder struct {
+	dir       string
+	def       string
+	separator string
+	comment   string
+	ns        string
+	opts      []Option
+}
+
+// NewBuilder returns an empty Builder, Dir and Default are required.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Dir sets the language files directory.
+func (b *Builder) Dir(dir string) *Builder {
+	b.dir = dir
+	return b
+}
+
+// Default sets the default language.
+func (b *Builder) Default(lang string) *Builder {
+	b.def = lang
+	return b
+}
+
+// Separator sets the key value separator, (=) if not set.
+func (b *Builder) Separator(separator string) *Builder {
+	b.separator = separator
+	return b
+}
+
+// Comment sets the comment symbol, (#) if not set.
+func (b *Builder) Comment(comment string) *Builder {
+	b.comment = comment
+	return b
+}
+
+// Namespace names the catalog: the SetFetcher func, pending translations and
+// logs see its keys as ns.key, so catalogs sharing keys can be told apart.
+func (b *Builder) Namespace(ns string) *Builder {
+	b.ns = ns
+	return b
+}
+
+// Strict adds Strict option.
+func (b *Builder) Strict() *Builder {
+	return b.Options(Strict())
+}
+
+// Options adds load options.
+func (b *Builder) Options(opts ...Option) *Builder {
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+// Build parses the catalog, the package catalog isn't modified.
+func (b *Builder) Build() (*Translator, error) {
+	if b.dir == "" {
+		return nil, errors.New("i18n: builder: dir not set")
+	}
+	if b.def == "" {
+		return nil, errors.New("i18n: builder: default language not set")
+	}
+	values, err := Parse(b.dir, b.separator, b.comment, b.opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Translator{values: values, def: b.def, ns: b.ns}, nil
+}
@@ -0,0 +1,201 @@
+package i18n
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en":    "hello:Hello\n;comment:x\ngreet:Hi %s\n",
+		"es":    "hello:Hola\n",
+		"es-MX": "greet:Qué onda %s\n",
+	})
+	defer os.RemoveAll(dir)
+
+	reset()
+	defer reset()
+	tr, err := NewBuilder().Dir(dir).Default("en").Separator(":").Comment(";").Build()
+	if err != nil {
+		t.Fatalf("build: %s", err)
+	}
+	if len(langs) != 0 {
+		t.Fatalf("expected package catalog untouched, got %v", langs)
+	}
+	if err := Load(dir, "en", ":", ";"); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	for _, lang := range []string{"en", "es", "es-MX", "fr"} {
+		for _, key := range []string{"hello", "greet", "comment", "none"} {
+			if s, expected := tr.Println(lang, key), Println(lang, key); s != expected {
+				t.Fatalf("%s:%s expected %q, got %q", lang, key, expected, s)
+			}
+		}
+	}
+	if s := tr.Default(); s != "en" {
+		t.Fatalf("expected default en, got %q", s)
+	}
+	if s := tr.Println("es-MX", "hello"); s != "Hola" {
+		t.Fatalf("expected Hola, got %q", s)
+	}
+	if s := tr.Printf("es-MX", "greet", "Ana"); s != "Qué onda Ana" {
+		t.Fatalf("expected Qué onda Ana, got %q", s)
+	}
+	if _, ok := tr.FuncMap()["i18n"]; !ok {
+		t.Fatalf("expected i18n func")
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en": "hello=Hello\nbroken line\n",
+	})
+	defer os.RemoveAll(dir)
+
+	reset()
+	if _, err := NewBuilder().Default("en").Build(); err == nil {
+		t.Fatalf("expected missing dir error")
+	}
+	if _, err := NewBuilder().Dir(dir).Build(); err == nil {
+		t.Fatalf("expected missing default error")
+	}
+	if _, err := NewBuilder().Dir(dir).Default("en").Strict().Build(); err == nil {
+		t.Fatalf("expected strict error")
+	}
+	if s := Println("en", "hello"); s != "hello" {
+		t.Fatalf("expected catalog untouched, got %q", s)
+	}
+	tr, err := NewBuilder().Dir(dir).Default("en").Build()
+	if err != nil {
+		t.Fatalf("expected lenient build, got %s", err)
+	}
+	if s := tr.Println("en", "hello"); s != "Hello" {
+		t.Fatalf("expected Hello, got %q", s)
+	}
+}
+
+func TestBuilderDefaults(t *testing.T) {
+	appDir := writeFiles(t, map[string]string{
+		"en": "hello=Hello\n",
+	})
+	defer os.RemoveAll(appDir)
+	libDir := writeFiles(t, map[string]string{
+		"en": "hello=Hi\n",
+		"es": "hello=Hola\n",
+		"fr": "hello=Salut\n",
+	})
+	defer os.RemoveAll(libDir)
+
+	reset()
+	defer reset()
+	if err := Load(appDir, "en", "", ""); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	es, err := NewBuilder().Dir(libDir).Default("es").Namespace("lib").Build()
+	if err != nil {
+		t.Fatalf("build es: %s", err)
+	}
+	fr, err := NewBuilder().Dir(libDir).Default("fr").Build()
+	if err != nil {
+		t.Fatalf("build fr: %s", err)
+	}
+	if s := Println("fr", "hello"); s != "Hello" {
+		t.Fatalf("expected package fallback unchanged, got %q", s)
+	}
+	if defLang != "en" {
+		t.Fatalf("expected package default en, got %q", defLang)
+	}
+	if s := es.Println("de", "hello"); s != "Hola" {
+		t.Fatalf("expected es default, got %q", s)
+	}
+	if s := fr.Println("de", "hello"); s != "Salut" {
+		t.Fatalf("expected fr default, got %q", s)
+	}
+}
+
+func TestTranslatorFuncMapNamed(t *testing.T) {
+	libDir := writeFiles(t, map[string]string{
+		"en": "title=Library\nok=OK\n",
+		"es": "title=Biblioteca\n",
+	})
+	defer os.RemoveAll(libDir)
+	appDir := writeFiles(t, map[string]string{
+		"es": "title=Aplicación\nnote=Línea uno\nbye=Adiós\n",
+		"en": "title=App\n",
+	})
+	defer os.RemoveAll(appDir)
+
+	reset()
+	lib, err := NewBuilder().Dir(libDir).Default("en").Namespace("lib").Build()
+	if err != nil {
+		t.Fatalf("build lib: %s", err)
+	}
+	app, err := NewBuilder().Dir(appDir).Default("es").Namespace("app").Build()
+	if err != nil {
+		t.Fatalf("build app: %s", err)
+	}
+
+	fnmap := lib.FuncMapNamed("lib")
+	for name, fn := range app.FuncMapNamed("app") {
+		fnmap[name] = fn
+	}
+	tmpl, err := template.New("page").Funcs(fnmap).Parse(
+		`{{lib_i18n "es" "title"}}|{{app_i18n "es" "title"}}|` +
+			`{{lib_i18n "fr" "ok"}}|{{app_i18n "fr" "bye"}}|{{app_i18nbr "en" "note"}}`)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatalf("execute: %s", err)
+	}
+	expected := "Biblioteca|Aplicación|OK|Adiós|Línea uno"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+	if s := lib.Println("es", "bye"); s != "bye" {
+		t.Fatalf("expected app keys hidden from lib, got %q", s)
+	}
+	if list := Languages(); len(list) != 0 {
+		t.Fatalf("expected catalogs out of package languages, got %v", list)
+	}
+	if list := Keys("es"); len(list) != 0 {
+		t.Fatalf("expected catalogs out of package keys, got %v", list)
+	}
+}
+
+func TestTranslatorFetcher(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en": "hello=Hello\n",
+	})
+	defer os.RemoveAll(dir)
+	reset()
+	defer reset()
+	tr, err := NewBuilder().Dir(dir).Default("en").Namespace("lib").Build()
+	if err != nil {
+		t.Fatalf("build: %s", err)
+	}
+	SetFetcher(func(lang, key string) (string, bool) {
+		if key == "lib.remote" {
+			return "Remote %s", true
+		}
+		return "", false
+	})
+	SetPendingTranslations(true)
+
+	if s := tr.Println("en", "remote"); s != "Remote %s" {
+		t.Fatalf("expected fetched value, got %q", s)
+	}
+	if s := tr.Printf("en", "remote", "x"); s != "Remote x" {
+		t.Fatalf("expected fetched value, got %q", s)
+	}
+	if s := tr.Println("es", "none"); s != "none" {
+		t.Fatalf("expected key without namespace, got %q", s)
+	}
+	list := PendingTranslations()
+	if len(list) != 2 || list[0].Key != "lib.remote" || list[1].Key != "lib.none" {
+		t.Fatalf("expected pending lookups, got %v", list)
+	}
+}
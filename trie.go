@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"sort"
+	"strings"
+)
+
+// trie is a key prefix tree.
+type trie struct {
+	children map[byte]*trie
+	// leaf is true if a key ends at the node.
+	leaf bool
+}
+
+var (
+	// keyIndex enables per language key tries, see SetKeyIndex.
+	keyIndex bool
+	// tries contains key tries by clean language, cleared by invalidate.
+	tries = make(map[string]*trie)
+)
+
+// SetKeyIndex enables a per language key index making KeysWithPrefix
+// faster on big catalogs, e.g. for editor autocomplete. Indexes use memory
+// proportional to keys length and are built on first query after each
+// catalog change. Disabled by default.
+func SetKeyIndex(enabled bool) {
+	mut.Lock()
+	defer mut.Unlock()
+	keyIndex = enabled
+	invalidate()
+}
+
+// KeysWithPrefix returns sorted keys of lang starting with prefix. It
+// doesn't follow the fallback chain.
+func KeysWithPrefix(lang, prefix string) []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	if !keyIndex {
+		var list []string
+		for _, key := range keys(lang) {
+			if strings.HasPrefix(key, prefix) {
+				list = append(list, key)
+			}
+		}
+		return list
+	}
+
+	node := trieFor(lang)
+	for i := 0; i < len(prefix) && node != nil; i++ {
+		node = node.children[prefix[i]]
+	}
+	if node == nil {
+		return nil
+	}
+	list := node.collect([]byte(prefix), nil)
+	sort.Strings(list)
+	return list
+}
+
+// trieFor returns the key trie of lang. Caller must hold mut.
+func trieFor(lang string) *trie {
+	lang = cleanLang(lang)
+	cacheMut.RLock()
+	t, ok := tries[lang]
+	cacheMut.RUnlock()
+	if ok {
+		return t
+	}
+
+	t = &trie{}
+	prefix := lang + ":"
+	for slug := range langs {
+		if strings.HasPrefix(slug, prefix) {
+			t.insert(slug[len(prefix):])
+		}
+	}
+	cacheMut.Lock()
+	tries[lang] = t
+	cacheMut.Unlock()
+	return t
+}
+
+func (t *trie) insert(key string) {
+	node := t
+	for i := 0; i < len(key); i++ {
+		child, ok := node.children[key[i]]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[byte]*trie)
+			}
+			child = &trie{}
+			node.children[key[i]] = child
+		}
+		node = child
+	}
+	node.leaf = true
+}
+
+// collect appends keys under t to list, path is the key prefix of t.
+func (t *trie) collect(path []byte, list []string) []string {
+	if t.leaf {
+		list = append(list, string(path))
+	}
+	for c, child := range t.children {
+		list = child.collect(append(path, c), list)
+	}
+	return list
+}
@@ -0,0 +1,54 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestKeysWithPrefix(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "menu.file=File\nmenu.files=Files\nmenu.edit=Edit\nhome=Home\nm=M\n",
+		"es": "menu.file=Archivo\n",
+	})
+	for _, indexed := range []bool{false, true} {
+		SetKeyIndex(indexed)
+		table := []struct {
+			Lang     string
+			Prefix   string
+			Expected string
+		}{
+			{"en", "menu.f", "menu.file,menu.files"},
+			{"en", "menu.", "menu.edit,menu.file,menu.files"},
+			{"en", "m", "m,menu.edit,menu.file,menu.files"},
+			{"en", "", "home,m,menu.edit,menu.file,menu.files"},
+			{"es-MX", "menu", ""},
+			{"es", "menu", "menu.file"},
+			{"en", "none", ""},
+		}
+		for i := range table {
+			x := table[i]
+			if s := strings.Join(KeysWithPrefix(x.Lang, x.Prefix), ","); s != x.Expected {
+				t.Errorf("index %v %s:%s expected %q, got %q", indexed, x.Lang, x.Prefix, x.Expected, s)
+			}
+		}
+	}
+}
+
+func benchmarkKeysWithPrefix(b *testing.B, indexed bool) {
+	var content bytes.Buffer
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&content, "section%d.key%d=Value\n", i%100, i)
+	}
+	setup(b, "en", map[string]string{"en": content.String()})
+	SetKeyIndex(indexed)
+	KeysWithPrefix("en", "section42.key1")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KeysWithPrefix("en", "section42.key1")
+	}
+}
+
+func BenchmarkKeysWithPrefixScan(b *testing.B) { benchmarkKeysWithPrefix(b, false) }
+func BenchmarkKeysWithPrefixTrie(b *testing.B) { benchmarkKeysWithPrefix(b, true) }
@@ -0,0 +1,38 @@
+package i18n
+
+import "strings"
+
+// trimPeriod enables PrintlnTrim trailing period removal.
+var trimPeriod bool
+
+// SetTrimTrailingPunctuation enables removing a trailing sentence period in
+// PrintlnTrim, so callers can add their own punctuation. Disabled by
+// default.
+func SetTrimTrailingPunctuation(enabled bool) {
+	mut.Lock()
+	defer mut.Unlock()
+	trimPeriod = enabled
+}
+
+// PrintlnTrim works like Println removing a trailing sentence period (. or
+// the ideographic 。) when SetTrimTrailingPunctuation is enabled. Ellipsis
+// (...) are kept.
+func PrintlnTrim(lang, key string) string {
+	s := Println(lang, key)
+	if !trimEnabled() || strings.HasSuffix(s, "..") {
+		return s
+	}
+	for _, p := range []string{".", "。"} {
+		if strings.HasSuffix(s, p) {
+			return s[:len(s)-len(p)]
+		}
+	}
+	return s
+}
+
+// trimEnabled reports if SetTrimTrailingPunctuation is enabled.
+func trimEnabled() bool {
+	mut.RLock()
+	defer mut.RUnlock()
+	return trimPeriod
+}
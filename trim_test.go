@@ -0,0 +1,33 @@
+package i18n
+
+import "testing"
+
+func TestPrintlnTrim(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "name=Full name.\nloading=Loading...\nplain=Email\n",
+		"ja": "name=氏名。\n",
+	})
+	defer reset()
+
+	if s := PrintlnTrim("en", "name"); s != "Full name." {
+		t.Fatalf("expected no trimming by default, got %q", s)
+	}
+
+	SetTrimTrailingPunctuation(true)
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"en", "name", "Full name"},
+		{"en", "loading", "Loading..."},
+		{"en", "plain", "Email"},
+		{"ja", "name", "氏名"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := PrintlnTrim(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ellipses contains ellipsis by language, others use ….
+var ellipses = map[string]string{
+	"zh": "……",
+}
+
+// Truncate returns the translation of key for lang cut to at most maxRunes
+// runes (not bytes) including the lang ellipsis, e.g. "Configuraci…" for
+// Configuración and 12 runes. Combining marks aren't separated from their
+// base character. Values within maxRunes are returned as Println does.
+// In templates:
+//
+//	{{i18ntrunc .Lang "title" 20}}
+func Truncate(lang, key string, maxRunes int) string {
+	return truncate(Println(lang, key), ellipsisFor(lang), maxRunes)
+}
+
+// truncate returns s cut to maxRunes runes including ellipsis, ellipsis is
+// skipped if it doesn't fit.
+func truncate(s, ellipsis string, maxRunes int) string {
+	if maxRunes < 1 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	n := maxRunes - utf8.RuneCountInString(ellipsis)
+	if n < 1 {
+		ellipsis, n = "", maxRunes
+	}
+
+	// cut is the byte index after n runes.
+	cut := 0
+	for i := 0; i < n; i++ {
+		_, size := utf8.DecodeRuneInString(s[cut:])
+		cut += size
+	}
+	// move back before the base character of combining marks.
+	for cut > 0 {
+		r, _ := utf8.DecodeRuneInString(s[cut:])
+		if !unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) {
+			break
+		}
+		_, size := utf8.DecodeLastRuneInString(s[:cut])
+		cut -= size
+	}
+	return strings.TrimRightFunc(s[:cut], unicode.IsSpace) + ellipsis
+}
+
+// ellipsisFor returns the ellipsis of lang or its base language.
+func ellipsisFor(lang string) string {
+	lang = cleanLang(lang)
+	if e, ok := ellipses[lang]; ok {
+		return e
+	}
+	if i := strings.IndexAny(lang, "-_"); i > -1 {
+		if e, ok := ellipses[lang[:i]]; ok {
+			return e
+		}
+	}
+	return "…"
+}
@@ -0,0 +1,54 @@
+package i18n
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "title=Settings and preferences\nshort=Home\n",
+		"es": "title=Configuración de la cuenta\n",
+		"fr": "title=Cafe\u0301 cre\u0300me\n",
+		"ja": "title=アカウントの設定を変更する\n",
+		"zh": "title=更改帐户设置和偏好\n",
+	})
+	defer reset()
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Max      int
+		Expected string
+	}{
+		{"en", "short", 10, "Home"},
+		{"en", "short", 4, "Home"},
+		{"en", "title", 10, "Settings…"},
+		{"en", "title", 9, "Settings…"},
+		{"es", "title", 14, "Configuración…"},
+		{"es", "title", 13, "Configuració…"},
+		// decomposed é isn't split.
+		{"fr", "title", 5, "Caf…"},
+		{"fr", "title", 6, "Cafe\u0301…"},
+		{"ja", "title", 5, "アカウン…"},
+		{"zh", "title", 6, "更改帐户……"},
+		{"zh-TW", "title", 2, "更改"},
+		{"en", "title", 1, "S"},
+		{"en", "title", 0, ""},
+	}
+	for _, x := range table {
+		if s := Truncate(x.Lang, x.Key, x.Max); s != x.Expected {
+			t.Errorf("%s:%s %d expected %q, got %q", x.Lang, x.Key, x.Max, x.Expected, s)
+		}
+	}
+
+	tmpl := template.Must(template.New("").Funcs(FuncMap).Parse(`{{i18ntrunc "en" "title" 9}}`))
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s := b.String(); s != "Settings…" {
+		t.Fatalf("expected Settings…, got %q", s)
+	}
+}
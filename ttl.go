@@ -0,0 +1,35 @@
+package i18n
+
+import "time"
+
+// timedEntry is a value set with AddTranslationTTL.
+type timedEntry struct {
+	value  string
+	expiry time.Time
+}
+
+// timed contains values with expiry by lang:key.
+var timed = make(map[string]timedEntry)
+
+// AddTranslationTTL sets value for lang+key until expiry, e.g. campaign
+// copy. It takes precedence over the loaded value, after expiry lookups
+// serve the loaded value or the fallback chain again.
+func AddTranslationTTL(lang, key, value string, expiry time.Time) {
+	mut.Lock()
+	defer mut.Unlock()
+	timed[bullet(lang, key)] = timedEntry{value, expiry}
+	invalidate()
+}
+
+// timedValue returns the unexpired timed value of slug (lang:key). Caller
+// must hold mut.
+func timedValue(slug string) (string, bool) {
+	if len(timed) < 1 {
+		return "", false
+	}
+	e, ok := timed[slug]
+	if !ok || !time.Now().Before(e.expiry) {
+		return "", false
+	}
+	return e.value, true
+}
@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddTranslationTTL(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "banner=Welcome\ncta=Buy\n",
+		"es": "banner=Bienvenido\n",
+	})
+
+	// fallback is cached before the campaign.
+	if s := Println("es", "cta"); s != "Buy" {
+		t.Fatalf("expected Buy, got %q", s)
+	}
+	AddTranslationTTL("es", "banner", "¡Rebajas!", time.Now().Add(time.Hour))
+	AddTranslationTTL("en", "banner", "Old sale", time.Now().Add(-time.Hour))
+	AddTranslationTTL("en", "cta", "Buy now", time.Now().Add(50*time.Millisecond))
+	AddTranslationTTL("fr", "promo", "Promo", time.Now().Add(-time.Minute))
+
+	table := []struct {
+		Lang     string
+		Key      string
+		Expected string
+	}{
+		{"es", "banner", "¡Rebajas!"},
+		{"en", "banner", "Welcome"},
+		{"fr", "banner", "Welcome"},
+		{"es", "cta", "Buy now"},
+		{"fr", "promo", "promo"},
+	}
+	for i := range table {
+		x := table[i]
+		if s := Println(x.Lang, x.Key); s != x.Expected {
+			t.Errorf("%s:%s expected %q, got %q", x.Lang, x.Key, x.Expected, s)
+		}
+	}
+	c := Freeze()
+
+	time.Sleep(60 * time.Millisecond)
+	if s := Println("es", "cta"); s != "Buy" {
+		t.Fatalf("expected loaded value after expiry, got %q", s)
+	}
+	if s := c.Println("es", "cta"); s != "Buy now" {
+		t.Fatalf("expected snapshot fixed at freeze time, got %q", s)
+	}
+}
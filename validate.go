@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ValidateDir validates every subdirectory of root as the language files
+// directory of a service, returning errors by subdirectory name:
+// reference language keys missing in other languages (regions of a loaded
+// base language inherit its keys) and values with a different number of
+// fmt args than the reference value, counting explicit indexes like %[2]s.
+//
+// Reference language is read from the .default file of the service, else
+// en if loaded, else the language with more keys. Services are parsed with
+// Parse, the catalog isn't modified. Services without errors aren't in the
+// map. err is only returned if root can't be read.
+func ValidateDir(root string) (map[string][]error, error) {
+	infos, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string][]error)
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, info.Name())
+		var errs []error
+		catalog, err := Parse(dir, "", "")
+		if err != nil {
+			errs = []error{err}
+		} else {
+			errs = validateCatalog(catalog, referenceLang(dir, catalog))
+		}
+		if len(errs) > 0 {
+			m[info.Name()] = errs
+		}
+	}
+	return m, nil
+}
+
+// referenceLang returns the reference language of a service catalog, see
+// ValidateDir.
+func referenceLang(dir string, catalog map[string]map[string]string) string {
+	b, err := ioutil.ReadFile(filepath.Join(dir, defaultFile))
+	if err == nil {
+		return cleanLang(strings.TrimSpace(string(b)))
+	}
+	if !os.IsNotExist(err) {
+		return ""
+	}
+	if _, ok := catalog["en"]; ok {
+		return "en"
+	}
+	var ref string
+	for lang, keys := range catalog {
+		if len(keys) > len(catalog[ref]) || (len(keys) == len(catalog[ref]) && lang < ref) {
+			ref = lang
+		}
+	}
+	return ref
+}
+
+// validateCatalog returns missing keys and fmt verb errors of catalog
+// languages against ref.
+func validateCatalog(catalog map[string]map[string]string, ref string) []error {
+	reference, ok := catalog[ref]
+	if !ok {
+		return []error{fmt.Errorf("i18n: reference language %q not found", ref)}
+	}
+	var errs []error
+	for lang, values := range catalog {
+		if lang == ref {
+			continue
+		}
+		var parent map[string]string
+		if i := strings.IndexAny(lang, "-_"); i > -1 {
+			parent = catalog[lang[:i]]
+		}
+		for key, want := range reference {
+			v, ok := values[key]
+			if !ok {
+				// regions inherit keys, missing are reported once for
+				// the base language.
+				if parent == nil {
+					errs = append(errs, fmt.Errorf("i18n: %s: missing key", bullet(lang, key)))
+				}
+				continue
+			}
+			if n, expected := countArgs(v), countArgs(want); n != expected {
+				errs = append(errs, fmt.Errorf("i18n: %s: %d fmt args, %s has %d", bullet(lang, key), n, bullet(ref, key), expected))
+			}
+		}
+	}
+	sort.Sort(errorList(errs))
+	return errs
+}
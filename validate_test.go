@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateDir(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"billing/en":      "invoice=Invoice %s\ntotal=Total %d %s\nbye=Bye\n",
+		"billing/es":      "invoice=Factura %s\ntotal=Total %d\n",
+		"billing/es-MX":   "invoice=Factura %s\n",
+		"search/.default": "es\n",
+		"search/es":       "query=Buscar %s\nmove=Mover a %[2]s desde %[1]s\n",
+		"search/en":       "query=Search %s\nmove=Move %s to %s\n",
+		"search/fr":       "other=Autre\n",
+		"healthy/en":      "ok=OK\n",
+		"healthy/de":      "ok=OK\n",
+		"broken/.default": "de\n",
+		"broken/en":       "ok=OK\n",
+		"README":          "not a service\n",
+	})
+	defer os.RemoveAll(root)
+
+	setup(t, "en", map[string]string{"en": "loaded=Loaded\n"})
+	m, err := ValidateDir(root)
+	if err != nil {
+		t.Fatalf("validate: %s", err)
+	}
+	expected := map[string][]string{
+		"billing": {
+			"i18n: es:bye: missing key",
+			"i18n: es:total: 1 fmt args, en:total has 2",
+		},
+		"search": {
+			"i18n: fr:move: missing key",
+			"i18n: fr:query: missing key",
+		},
+		"broken": {
+			`i18n: reference language "de" not found`,
+		},
+	}
+	if len(m) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, m)
+	}
+	for name, list := range expected {
+		errs := m[name]
+		if len(errs) != len(list) {
+			t.Fatalf("%s expected %v, got %v", name, list, errs)
+		}
+		for i := range list {
+			if errs[i].Error() != list[i] {
+				t.Errorf("%s expected %q, got %q", name, list[i], errs[i])
+			}
+		}
+	}
+	if s := Println("en", "loaded"); s != "Loaded" || len(Keys("en")) != 1 {
+		t.Fatalf("expected catalog untouched")
+	}
+
+	if _, err := ValidateDir(filepath.Join(root, "none")); err == nil {
+		t.Fatalf("expected missing root error")
+	}
+}
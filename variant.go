@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	rnd    = rand.New(rand.NewSource(time.Now().UnixNano()))
+	rndMut sync.Mutex
+)
+
+// SetRandSource replaces the random source used by Variant.
+//
+// Useful to get deterministic variants in tests.
+func SetRandSource(src rand.Source) {
+	rndMut.Lock()
+	defer rndMut.Unlock()
+	rnd = rand.New(src)
+}
+
+// Variant returns a random value between keys prefix.1, prefix.2 ... prefix.N
+// for lang. Variants must be numbered consecutively starting at 1 and are
+// taken from the language serving prefix.1.
+//
+// If no variant is found prefix is returned.
+func Variant(lang, prefix string) string {
+	values := variantValues(lang, prefix)
+	if len(values) < 1 {
+		return prefix
+	}
+	rndMut.Lock()
+	n := rnd.Intn(len(values))
+	rndMut.Unlock()
+	return values[n]
+}
+
+// WeightedVariant works like Variant choosing prefix.N proportionally to its
+// weight, read from key prefix.N.weight:
+//
+//	cta.1=Buy now
+//	cta.1.weight=70
+//	cta.2=Get it today
+//	cta.2.weight=30
+//
+// Missing or invalid weights count as 1, weight 0 disables the variant. If
+// every variant is disabled the translation of prefix itself is returned.
+func WeightedVariant(lang, prefix string) string {
+	values, weights, total, base, ok := weightedVariants(lang, prefix)
+	if len(values) < 1 || total < 1 && !ok {
+		return prefix
+	}
+	if total < 1 {
+		return base
+	}
+	rndMut.Lock()
+	n := rnd.Intn(total)
+	rndMut.Unlock()
+	for i := range weights {
+		if n < weights[i] {
+			return values[i]
+		}
+		n -= weights[i]
+	}
+	return values[len(values)-1]
+}
+
+// variantValues returns variants of prefix for lang.
+func variantValues(lang, prefix string) []string {
+	mut.RLock()
+	defer mut.RUnlock()
+	values, _ := variants(lang, prefix)
+	return values
+}
+
+// variants returns values of prefix.1 ... prefix.N for lang like Println
+// gets them and the language serving them. Caller must hold mut.
+func variants(lang, prefix string) ([]string, string) {
+	var values []string
+	v, served, ok := find(lang, fallbackLevels, prefix+".1")
+	for i := 2; ok; i++ {
+		values = append(values, v)
+		v, _, ok = find(served, 1, prefix+"."+strconv.Itoa(i))
+	}
+	return values, served
+}
+
+// weightedVariants returns variants of prefix for lang with their weights
+// and total, if total is 0 the translation of prefix too.
+func weightedVariants(lang, prefix string) ([]string, []int, int, string, bool) {
+	mut.RLock()
+	defer mut.RUnlock()
+	values, served := variants(lang, prefix)
+	weights := make([]int, len(values))
+	var total int
+	p := normalizeKey(lang, prefix)
+	for i := range values {
+		weights[i] = 1
+		if s, ok := value(bullet(served, p+"."+strconv.Itoa(i+1)+".weight")); ok {
+			if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil && n >= 0 {
+				weights[i] = n
+			}
+		}
+		total += weights[i]
+	}
+	if len(values) < 1 || total > 0 {
+		return values, weights, total, "", false
+	}
+	base, ok := lookup(lang, prefix)
+	return values, weights, total, base, ok
+}
@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestVariant(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "cta.1=Buy now\ncta.2=Get it today\ncta.3=Order now\n",
+		"es": "cta.1=Compra ya\n",
+	})
+
+	SetRandSource(rand.NewSource(1))
+	seen := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		seen[Variant("en", "cta")]++
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 variants, got %v", seen)
+	}
+
+	// same seed same sequence.
+	SetRandSource(rand.NewSource(42))
+	a := []string{Variant("en", "cta"), Variant("en", "cta"), Variant("en", "cta")}
+	SetRandSource(rand.NewSource(42))
+	b := []string{Variant("en", "cta"), Variant("en", "cta"), Variant("en", "cta")}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("expected deterministic variants, got %v and %v", a, b)
+		}
+	}
+
+	if v := Variant("es-MX", "cta"); v != "Compra ya" {
+		t.Fatalf("expected region fallback, got %q", v)
+	}
+	if v := Variant("en", "missing"); v != "missing" {
+		t.Fatalf("expected prefix on miss, got %q", v)
+	}
+}
+
+func TestWeightedVariant(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "cta.1=Buy now\ncta.1.weight=70\ncta.2=Get it today\ncta.2.weight=30\n" +
+			"off.1=A\noff.1.weight=0\noff.2=B\n" +
+			"zero=Base\nzero.1=First\nzero.1.weight=0\n" +
+			"nobase.1=Only\nnobase.1.weight=0\n",
+		"es": "cta.1=Compra ya\ncta.2=Consíguelo hoy\n",
+	})
+
+	SetRandSource(rand.NewSource(7))
+	const draws = 10000
+	count := make(map[string]int)
+	for i := 0; i < draws; i++ {
+		count[WeightedVariant("en", "cta")]++
+	}
+	if len(count) != 2 {
+		t.Fatalf("unexpected variants %v", count)
+	}
+	if p := count["Buy now"] * 100 / draws; p < 67 || p > 73 {
+		t.Fatalf("expected ~70%% for cta.1, got %d%% (%v)", p, count)
+	}
+
+	// weights are read from es serving the variants, not inherited from en.
+	count = make(map[string]int)
+	for i := 0; i < draws; i++ {
+		count[WeightedVariant("es", "cta")]++
+	}
+	if p := count["Compra ya"] * 100 / draws; p < 47 || p > 53 {
+		t.Fatalf("expected ~50%% for cta.1, got %d%% (%v)", p, count)
+	}
+
+	for i := 0; i < 100; i++ {
+		if s := WeightedVariant("en", "off"); s != "B" {
+			t.Fatalf("expected disabled variant never selected, got %q", s)
+		}
+	}
+	if s := WeightedVariant("en", "zero"); s != "Base" {
+		t.Fatalf("expected base key when all weights are zero, got %q", s)
+	}
+	if s := WeightedVariant("en", "nobase"); s != "nobase" {
+		t.Fatalf("expected prefix when all weights are zero and no base, got %q", s)
+	}
+	if s := WeightedVariant("en", "none"); s != "none" {
+		t.Fatalf("expected prefix on miss, got %q", s)
+	}
+}
+
+func TestVariantPipelinePanic(t *testing.T) {
+	setup(t, "en", map[string]string{
+		"en": "cta.1=Buy now\ncta.2=@alias(buy)\nbuy=Get it {@name}\nname=today\n",
+	})
+	defer reset()
+
+	SetRandSource(rand.NewSource(1))
+	count := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		count[Variant("en", "cta")]++
+	}
+	if len(count) != 2 || count["Get it today"] < 1 {
+		t.Fatalf("expected aliases and references followed, got %v", count)
+	}
+
+	SetPipeline(func(string) string { panic("pipeline") })
+	for _, fn := range []func(string, string) string{Variant, WeightedVariant} {
+		func() {
+			defer func() { recover() }()
+			fn("en", "cta")
+		}()
+	}
+	// a leaked read lock blocks writers.
+	SetPipeline()
+}